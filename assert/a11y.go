@@ -0,0 +1,454 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// HTMLAccessibilityTreeEqual asserts that the two arguments have equivalent
+// accessibility trees. Accepts strings, byte arrays, *html.Node objects, or
+// goquery selection. A simplified accessibility tree is extracted from each
+// document, consisting of the role, accessible name and ARIA attributes of
+// each element which has a role, explicit or implicit, along with any text.
+// Elements without a role, such as <div> and <span>, and hidden elements do
+// not appear in the tree, so purely presentational differences in markup are
+// ignored. On failure, the first differing node is reported by its path of
+// roles and names.
+func HTMLAccessibilityTreeEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expDoc, err := toHTMLNode(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid expected document: %s", err), msgAndArgs...)
+	}
+	actDoc, err := toHTMLNode(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid actual document: %s", err), msgAndArgs...)
+	}
+	expTree := accessibilityTree(goquery.NewDocumentFromNode(expDoc).Selection)
+	actTree := accessibilityTree(goquery.NewDocumentFromNode(actDoc).Selection)
+	path, ok := firstAXDifference(expTree, actTree, nil)
+	if ok {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, fmt.Sprintf("Accessibility trees differ at %s", strings.Join(path, " > ")),
+		expTree.String(), actTree.String(), o, msgAndArgs...)
+}
+
+// HTMLAccessibilityTreeEqual asserts that the two arguments have equivalent
+// accessibility trees. Accepts strings, byte arrays, *html.Node objects, or
+// goquery selection. A simplified accessibility tree is extracted from each
+// document, consisting of the role, accessible name and ARIA attributes of
+// each element which has a role, explicit or implicit, along with any text.
+// Elements without a role, such as <div> and <span>, and hidden elements do
+// not appear in the tree, so purely presentational differences in markup are
+// ignored. On failure, the first differing node is reported by its path of
+// roles and names.
+func (a *Assertions) HTMLAccessibilityTreeEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLAccessibilityTreeEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// axNode is a node of a simplified accessibility tree.
+type axNode struct {
+	role     string
+	name     string
+	attrs    []string
+	children []*axNode
+}
+
+// label returns the role and name of n, as used in paths.
+func (n *axNode) label() string {
+	if n.name == "" {
+		return n.role
+	}
+	return fmt.Sprintf("%s %q", n.role, n.name)
+}
+
+func (n *axNode) String() string {
+	buf := &bytes.Buffer{}
+	for _, c := range n.children {
+		c.write(buf, 0)
+	}
+	return buf.String()
+}
+
+func (n *axNode) write(buf *bytes.Buffer, depth int) {
+	buf.WriteString(strings.Repeat("  ", depth))
+	buf.WriteString(n.label())
+	for _, attr := range n.attrs {
+		buf.WriteString(" " + attr)
+	}
+	buf.WriteRune('\n')
+	for _, c := range n.children {
+		c.write(buf, depth+1)
+	}
+}
+
+// firstAXDifference returns the path to the first node at which e and a
+// differ, or false if they are equivalent.
+func firstAXDifference(e, a *axNode, path []string) ([]string, bool) {
+	if e.role != a.role || e.name != a.name || strings.Join(e.attrs, " ") != strings.Join(a.attrs, " ") {
+		return append(path, e.label()), false
+	}
+	if e.role != "" {
+		path = append(path, e.label())
+	}
+	for i := 0; i < len(e.children) || i < len(a.children); i++ {
+		switch {
+		case i >= len(a.children):
+			return append(path, e.children[i].label()+" (missing)"), false
+		case i >= len(e.children):
+			return append(path, a.children[i].label()+" (unexpected)"), false
+		}
+		if p, ok := firstAXDifference(e.children[i], a.children[i], path); !ok {
+			return p, false
+		}
+	}
+	if len(path) == 0 {
+		path = append(path, "(root)")
+	}
+	return path, true
+}
+
+// accessibilityTree extracts the accessibility tree of doc.
+func accessibilityTree(doc *goquery.Selection) *axNode {
+	root := &axNode{}
+	b := &axBuilder{doc: doc}
+	doc.Find("body").Each(func(_ int, body *goquery.Selection) {
+		b.children(root, body)
+	})
+	root.finish()
+	return root
+}
+
+// finish collapses the whitespace of the text beneath n, removing any text
+// nodes which are left empty.
+func (n *axNode) finish() {
+	children := n.children[:0]
+	for _, c := range n.children {
+		if c.role == "text" {
+			if c.name = collapseSpace(c.name); c.name == "" {
+				continue
+			}
+		}
+		c.finish()
+		children = append(children, c)
+	}
+	n.children = children
+}
+
+type axBuilder struct {
+	doc *goquery.Selection
+}
+
+// children appends the accessibility nodes for the contents of s to parent.
+func (b *axBuilder) children(parent *axNode, s *goquery.Selection) {
+	s.Contents().Each(func(_ int, c *goquery.Selection) {
+		node := c.Get(0)
+		switch node.Type {
+		case html.TextNode:
+			parent.appendText(node.Data)
+		case html.ElementNode:
+			b.element(parent, c)
+		}
+	})
+}
+
+func (b *axBuilder) element(parent *axNode, s *goquery.Selection) {
+	if axHidden(s) {
+		return
+	}
+	role := axRole(s)
+	if role == "" || role == "presentation" || role == "none" {
+		// Block elements separate the text on either side of them.
+		inline := inlineElements[goquery.NodeName(s)]
+		if !inline {
+			parent.appendText(" ")
+		}
+		b.children(parent, s)
+		if !inline {
+			parent.appendText(" ")
+		}
+		return
+	}
+	n := &axNode{role: role, name: b.name(s, role), attrs: axAttrs(s, role)}
+	parent.children = append(parent.children, n)
+	if nameFromContent[role] {
+		// The content already forms the name; only nested roles remain.
+		s.Children().Each(func(_ int, c *goquery.Selection) {
+			b.element(n, c)
+		})
+		for i := 0; i < len(n.children); i++ {
+			if n.children[i].role == "text" {
+				n.children = append(n.children[:i], n.children[i+1:]...)
+				i--
+			}
+		}
+		return
+	}
+	b.children(n, s)
+}
+
+// appendText appends text to n, merging it with any text immediately
+// preceding it, so that inline markup does not split it.
+func (n *axNode) appendText(text string) {
+	if last := len(n.children) - 1; last >= 0 && n.children[last].role == "text" {
+		n.children[last].name += text
+		return
+	}
+	n.children = append(n.children, &axNode{role: "text", name: text})
+}
+
+// inlineElements lists the elements without a role which do not separate the
+// surrounding text.
+var inlineElements = map[string]bool{
+	"abbr": true, "b": true, "bdi": true, "bdo": true, "cite": true,
+	"code": true, "data": true, "dfn": true, "em": true, "i": true,
+	"kbd": true, "label": true, "mark": true, "q": true, "s": true,
+	"samp": true, "small": true, "span": true, "strong": true, "sub": true,
+	"sup": true, "time": true, "u": true, "var": true,
+}
+
+func axHidden(s *goquery.Selection) bool {
+	switch goquery.NodeName(s) {
+	case "script", "style", "template", "noscript", "head":
+		return true
+	}
+	if _, ok := s.Attr("hidden"); ok {
+		return true
+	}
+	if v, _ := s.Attr("aria-hidden"); v == "true" {
+		return true
+	}
+	if v, _ := s.Attr("type"); goquery.NodeName(s) == "input" && v == "hidden" {
+		return true
+	}
+	return false
+}
+
+// implicitRoles maps element names to the ARIA roles they have implicitly.
+var implicitRoles = map[string]string{
+	"article":  "article",
+	"aside":    "complementary",
+	"button":   "button",
+	"dialog":   "dialog",
+	"footer":   "contentinfo",
+	"form":     "form",
+	"h1":       "heading",
+	"h2":       "heading",
+	"h3":       "heading",
+	"h4":       "heading",
+	"h5":       "heading",
+	"h6":       "heading",
+	"header":   "banner",
+	"hr":       "separator",
+	"img":      "img",
+	"li":       "listitem",
+	"main":     "main",
+	"nav":      "navigation",
+	"ol":       "list",
+	"option":   "option",
+	"progress": "progressbar",
+	"select":   "combobox",
+	"table":    "table",
+	"td":       "cell",
+	"textarea": "textbox",
+	"th":       "columnheader",
+	"tr":       "row",
+	"ul":       "list",
+}
+
+// inputRoles maps input types to their implicit roles.
+var inputRoles = map[string]string{
+	"button":   "button",
+	"checkbox": "checkbox",
+	"email":    "textbox",
+	"image":    "button",
+	"number":   "spinbutton",
+	"radio":    "radio",
+	"range":    "slider",
+	"reset":    "button",
+	"search":   "searchbox",
+	"submit":   "button",
+	"tel":      "textbox",
+	"text":     "textbox",
+	"url":      "textbox",
+}
+
+// nameFromContent lists the roles whose accessible name may be computed from
+// their content.
+var nameFromContent = map[string]bool{
+	"button":       true,
+	"cell":         true,
+	"checkbox":     true,
+	"columnheader": true,
+	"heading":      true,
+	"link":         true,
+	"menuitem":     true,
+	"option":       true,
+	"radio":        true,
+	"rowheader":    true,
+	"tab":          true,
+	"tooltip":      true,
+}
+
+func axRole(s *goquery.Selection) string {
+	if role, ok := s.Attr("role"); ok {
+		if fields := strings.Fields(role); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	name := goquery.NodeName(s)
+	switch name {
+	case "a", "area":
+		if _, ok := s.Attr("href"); ok {
+			return "link"
+		}
+		return ""
+	case "input":
+		typ, _ := s.Attr("type")
+		if typ == "" {
+			typ = "text"
+		}
+		return inputRoles[strings.ToLower(typ)]
+	case "section":
+		if s.AttrOr("aria-label", "") != "" || s.AttrOr("aria-labelledby", "") != "" {
+			return "region"
+		}
+		return ""
+	}
+	return implicitRoles[name]
+}
+
+// name computes the accessible name of s.
+func (b *axBuilder) name(s *goquery.Selection, role string) string {
+	if ids := strings.Fields(s.AttrOr("aria-labelledby", "")); len(ids) > 0 {
+		var parts []string
+		for _, id := range ids {
+			b.doc.Find("[id]").EachWithBreak(func(_ int, l *goquery.Selection) bool {
+				if l.AttrOr("id", "") == id {
+					parts = append(parts, l.Text())
+					return false
+				}
+				return true
+			})
+		}
+		return collapseSpace(strings.Join(parts, " "))
+	}
+	if label := collapseSpace(s.AttrOr("aria-label", "")); label != "" {
+		return label
+	}
+	switch goquery.NodeName(s) {
+	case "img", "area":
+		if alt, ok := s.Attr("alt"); ok {
+			return collapseSpace(alt)
+		}
+	case "input", "select", "textarea":
+		if typ := s.AttrOr("type", ""); typ == "submit" || typ == "reset" || typ == "button" {
+			if v := collapseSpace(s.AttrOr("value", "")); v != "" {
+				return v
+			}
+		}
+		if id := s.AttrOr("id", ""); id != "" {
+			var label string
+			b.doc.Find("label[for]").EachWithBreak(func(_ int, l *goquery.Selection) bool {
+				if l.AttrOr("for", "") == id {
+					label = l.Text()
+					return false
+				}
+				return true
+			})
+			if label != "" {
+				return collapseSpace(label)
+			}
+		}
+		if l := s.ParentsFiltered("label"); l.Length() > 0 {
+			return collapseSpace(l.First().Text())
+		}
+	}
+	if nameFromContent[role] {
+		if text := b.contentName(s); text != "" {
+			return text
+		}
+	}
+	return collapseSpace(s.AttrOr("title", ""))
+}
+
+// contentName computes a name from the content of s, skipping hidden
+// descendants and using the alternative text of images.
+func (b *axBuilder) contentName(s *goquery.Selection) string {
+	var parts []string
+	s.Contents().Each(func(_ int, c *goquery.Selection) {
+		node := c.Get(0)
+		switch node.Type {
+		case html.TextNode:
+			parts = append(parts, node.Data)
+		case html.ElementNode:
+			if axHidden(c) {
+				return
+			}
+			sep := ""
+			if !inlineElements[goquery.NodeName(c)] {
+				sep = " "
+			}
+			switch label := c.AttrOr("aria-label", ""); {
+			case goquery.NodeName(c) == "img":
+				parts = append(parts, sep+c.AttrOr("alt", "")+sep)
+			case label != "":
+				parts = append(parts, sep+label+sep)
+			default:
+				parts = append(parts, sep+b.contentName(c)+sep)
+			}
+		}
+	})
+	return collapseSpace(strings.Join(parts, ""))
+}
+
+// axAttrs returns the ARIA attributes of s, along with the heading level for
+// headings, as sorted key=value pairs. aria-label and aria-labelledby, which
+// contribute to the name, are omitted.
+func axAttrs(s *goquery.Selection, role string) []string {
+	var attrs []string
+	for _, attr := range s.Get(0).Attr {
+		if !strings.HasPrefix(attr.Key, "aria-") {
+			continue
+		}
+		switch attr.Key {
+		case "aria-label", "aria-labelledby", "aria-hidden":
+			continue
+		}
+		attrs = append(attrs, fmt.Sprintf("%s=%q", attr.Key, collapseSpace(attr.Val)))
+	}
+	if role == "heading" && s.AttrOr("aria-level", "") == "" {
+		if name := goquery.NodeName(s); len(name) == 2 && name[0] == 'h' {
+			attrs = append(attrs, fmt.Sprintf("aria-level=%q", name[1:]))
+		}
+	}
+	switch goquery.NodeName(s) {
+	case "input":
+		if _, ok := s.Attr("checked"); ok && s.AttrOr("aria-checked", "") == "" {
+			attrs = append(attrs, `aria-checked="true"`)
+		}
+		fallthrough
+	case "button", "select", "textarea":
+		if _, ok := s.Attr("disabled"); ok && s.AttrOr("aria-disabled", "") == "" {
+			attrs = append(attrs, `aria-disabled="true"`)
+		}
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,153 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestAccessibilityTree(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "empty",
+			html: ``,
+			want: ``,
+		},
+		{
+			name: "presentational elements dropped",
+			html: `<div class="x"><span>Some <em>emphasized</em></span> text</div>`,
+			want: "text \"Some emphasized text\"\n",
+		},
+		{
+			name: "block elements separate text",
+			html: `<div>one</div><div>two</div>`,
+			want: "text \"one two\"\n",
+		},
+		{
+			name: "hidden elements dropped",
+			html: `<p>shown</p><p hidden>a</p><p aria-hidden="true">b</p><script>c</script><input type="hidden" value="d">`,
+			want: "text \"shown\"\n",
+		},
+		{
+			name: "nested roles",
+			html: `<nav aria-label="Main"><ul><li><a href="/">Home</a></li></ul></nav>`,
+			want: "navigation \"Main\"\n  list\n    listitem\n      link \"Home\"\n",
+		},
+		{
+			name: "anchor without href has no role",
+			html: `<a name="top">Top</a>`,
+			want: "text \"Top\"\n",
+		},
+		{
+			name: "explicit role",
+			html: `<div role="alert button">Saved</div><ul role="presentation"><li role="none">x</li></ul>`,
+			want: "alert\n  text \"Saved\"\ntext \"x\"\n",
+		},
+		{
+			name: "heading level",
+			html: `<h2>Title</h2><div role="heading" aria-level="4">Sub</div>`,
+			want: "heading \"Title\" aria-level=\"2\"\nheading \"Sub\" aria-level=\"4\"\n",
+		},
+		{
+			name: "name from content",
+			html: `<button><img src="x.png" alt="Save"> <span hidden>now</span>file</button>`,
+			want: "button \"Save file\"\n  img \"Save\"\n",
+		},
+		{
+			name: "name from aria-labelledby",
+			html: `<span id="l1">First</span><span id="l2">Second</span><section aria-labelledby="l1 l2">x</section>`,
+			want: "text \"FirstSecond\"\nregion \"First Second\"\n  text \"x\"\n",
+		},
+		{
+			name: "name from label",
+			html: `<label for="e">Email</label><input id="e" type="email"><label>Age <input type="number"></label>`,
+			want: "text \"Email\"\ntextbox \"Email\"\ntext \"Age\"\nspinbutton \"Age\"\n",
+		},
+		{
+			name: "name from value and title",
+			html: `<input type="submit" value="Send"><input title="Search" type="search">`,
+			want: "button \"Send\"\nsearchbox \"Search\"\n",
+		},
+		{
+			name: "states",
+			html: `<input type="checkbox" checked disabled aria-describedby="d"><button aria-pressed="true">B</button>`,
+			want: "checkbox aria-checked=\"true\" aria-describedby=\"d\" aria-disabled=\"true\"\nbutton \"B\" aria-pressed=\"true\"\n",
+		},
+		{
+			name: "section without a name has no role",
+			html: `<section><p>x</p></section>`,
+			want: "text \"x\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := accessibilityTree(doc.Selection).String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLAccessibilityTreeEqual(t *testing.T) {
+	const page = `<nav aria-label="Main"><ul><li><a href="/">Home</a></li><li><a href="/about"><b>Ab</b>out</a></li></ul></nav>
+<h2>Title</h2><p>Some <em>text</em></p><button disabled>Go</button><img src="logo.png" alt="Logo"><script>track()</script>`
+	tests := []struct {
+		name   string
+		actual string
+		want   string
+	}{
+		{
+			name: "presentational differences",
+			actual: `<div><nav aria-label="Main"><ul class="menu"><li><span><a href="/" class="c">Home</a></span></li><li><a href="/about">About</a></li></ul></nav></div>
+<div><h2 class="t">Title</h2></div><div>Some text</div><span hidden>secret</span><button disabled="disabled">Go</button><img alt="Logo" src="other.png">`,
+		},
+		{
+			name:   "differing name",
+			actual: strings.Replace(page, "<b>Ab</b>out", "Contact", 1),
+			want:   "Accessibility trees differ at navigation \"Main\" > list > listitem > link \"About\"\n-      link \"About\"\n+      link \"Contact\"",
+		},
+		{
+			name:   "differing attribute",
+			actual: strings.Replace(page, "<h2>Title</h2>", "<h3>Title</h3>", 1),
+			want:   "Accessibility trees differ at heading \"Title\"\n-heading \"Title\" aria-level=\"2\"\n+heading \"Title\" aria-level=\"3\"",
+		},
+		{
+			name:   "missing node",
+			actual: strings.Replace(page, `<img src="logo.png" alt="Logo">`, "", 1),
+			want:   "Accessibility trees differ at img \"Logo\" (missing)",
+		},
+		{
+			name:   "unexpected node",
+			actual: page + `<footer>Fine print</footer>`,
+			want:   "Accessibility trees differ at contentinfo (unexpected)",
+		},
+		{
+			name:   "differing text",
+			actual: strings.Replace(page, "Some <em>text</em>", "Other text", 1),
+			want:   "Accessibility trees differ at text \"Some text\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := HTMLAccessibilityTreeEqual(m, page, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("HTMLAccessibilityTreeEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package assert
+
+import "fmt"
+
+// EqualAny asserts that actual is deeply equal to at least one of the
+// candidates. This is useful when output is nondeterministic, but limited to
+// a known set of valid forms. On failure, a diff against the closest
+// candidate, as measured by the number of differing leaf values, is shown.
+// The comparison options accepted by DeepEqual also apply.
+func EqualAny(t TestingT, actual interface{}, candidates []interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if len(candidates) == 0 {
+		return Fail(t, "No candidates provided", msgAndArgs...)
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	opts := o.compare
+	masked := len(opts.IgnoreFields) > 0 || len(opts.IgnorePaths) > 0
+	if masked {
+		actual = maskFields(actual, &opts)
+	}
+	closest, closestDiffs := 0, -1
+	for i, candidate := range candidates {
+		if masked {
+			candidate = maskFields(candidate, &opts)
+		}
+		n := len(differences(candidate, actual, &opts))
+		if n == 0 {
+			return true
+		}
+		if closestDiffs < 0 || n < closestDiffs {
+			closest, closestDiffs = i, n
+		}
+	}
+	expected := candidates[closest]
+	if masked {
+		expected = maskFields(expected, &opts)
+	}
+	return failInterfaceDiff(t, fmt.Sprintf("Value matches none of %d candidates; closest is candidate %d, with %d differences",
+		len(candidates), closest, closestDiffs), expected, actual, o, msgAndArgs...)
+}
+
+// EqualAny asserts that actual is deeply equal to at least one of the
+// candidates. This is useful when output is nondeterministic, but limited to
+// a known set of valid forms. On failure, a diff against the closest
+// candidate, as measured by the number of differing leaf values, is shown.
+// The comparison options accepted by DeepEqual also apply.
+func (a *Assertions) EqualAny(actual interface{}, candidates []interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualAny(a.t, actual, candidates, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,91 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type alternative struct {
+	ID    int
+	Names []string
+	Score float64
+}
+
+func TestEqualAny(t *testing.T) {
+	candidates := []interface{}{
+		alternative{ID: 1, Names: []string{"a", "b"}},
+		alternative{ID: 1, Names: []string{"b", "a"}},
+	}
+	tests := []struct {
+		name   string
+		actual interface{}
+		opts   []interface{}
+		want   string
+	}{
+		{
+			name:   "first candidate",
+			actual: alternative{ID: 1, Names: []string{"a", "b"}},
+		},
+		{
+			name:   "second candidate",
+			actual: alternative{ID: 1, Names: []string{"b", "a"}},
+		},
+		{
+			name:   "no candidate",
+			actual: alternative{ID: 2, Names: []string{"b", "a"}},
+			want:   "Value matches none of 2 candidates; closest is candidate 1, with 1 differences",
+		},
+		{
+			name:   "closest by leaves",
+			actual: alternative{ID: 1, Names: []string{"b", "c"}},
+			want:   "closest is candidate 1, with 1 differences",
+		},
+		{
+			name:   "different type",
+			actual: &alternative{ID: 1, Names: []string{"a", "b"}},
+			want:   "Value matches none of 2 candidates",
+		},
+		{
+			name:   "float tolerance",
+			actual: alternative{ID: 1, Names: []string{"b", "a"}, Score: 0.001},
+			opts:   []interface{}{WithFloatTolerance(0.01)},
+		},
+		{
+			name:   "without float tolerance",
+			actual: alternative{ID: 1, Names: []string{"b", "a"}, Score: 0.001},
+			want:   "closest is candidate 1, with 1 differences",
+		},
+		{
+			name:   "ignored field",
+			actual: alternative{ID: 3, Names: []string{"a", "b"}},
+			opts:   []interface{}{WithIgnoredFields("ID")},
+		},
+		{
+			name:   "unordered slices",
+			actual: alternative{ID: 1, Names: []string{"a", "b"}},
+			opts:   []interface{}{WithUnorderedSlices()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := EqualAny(m, tt.actual, candidates, tt.opts...)
+			if got != (tt.want == "") {
+				t.Fatalf("EqualAny returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
+
+func TestEqualAnyNoCandidates(t *testing.T) {
+	m := &mockT{}
+	if EqualAny(m, 1, nil) {
+		t.Fatal("EqualAny passed with no candidates")
+	}
+	if want := "No candidates provided"; !strings.Contains(m.output(), want) {
+		t.Errorf("failure does not contain %q:\n%s", want, m.output())
+	}
+}
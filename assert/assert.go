@@ -12,7 +12,6 @@ import (
 	"github.com/PuerkitoBio/goquery"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pkg/errors"
-	"github.com/pmezard/go-difflib/difflib"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,28 +21,79 @@ type TestingT interface {
 	FailNow()
 }
 
+// tHelper is implemented by *testing.T. If a TestingT also implements it,
+// assertions mark themselves as test helpers, so that failures are attributed
+// to the calling test.
+type tHelper interface {
+	Helper()
+}
+
 // Assertions provides assertion methods around the TestingT interface.
 type Assertions struct {
 	*assert.Assertions
-	t TestingT
+	t    TestingT
+	opts []Option
 }
 
-// New makes a new Assertions object for the specified TestingT.
-func New(t TestingT) *Assertions {
+// New makes a new Assertions object for the specified TestingT. Any opts are
+// applied to every assertion made through it, before any options passed to
+// the individual assertion.
+func New(t TestingT, opts ...Option) *Assertions {
 	return &Assertions{
 		Assertions: assert.New(t),
 		t:          t,
+		opts:       opts,
+	}
+}
+
+// withOptions returns msgAndArgs, preceded by the options of a.
+func (a *Assertions) withOptions(msgAndArgs []interface{}) []interface{} {
+	if len(a.opts) == 0 {
+		return msgAndArgs
+	}
+	args := make([]interface{}, 0, len(a.opts)+len(msgAndArgs))
+	for _, opt := range a.opts {
+		args = append(args, opt)
 	}
+	return append(args, msgAndArgs...)
 }
 
 // FailDiff reports a failure through, including a contextual diff
 func FailDiff(t TestingT, failureMessage, diff string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return reportDiff(t, failureMessage, diff, o, msgAndArgs...)
+}
+
+// reportDiff reports a failure, including the diff, rendered according to o.
+func reportDiff(t TestingT, failureMessage, diff string, o *options, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if diff == "" {
-		return Fail(t, failureMessage, msgAndArgs...)
+		return fail(t, failureMessage, o, msgAndArgs...)
 	}
 	message := messageFromMsgAndArgs(msgAndArgs...)
+	reportFailure(t, FailureRecord{
+		Error:    failureMessage,
+		Message:  message,
+		Expected: o.reportExpected,
+		Actual:   o.reportActual,
+		Diff:     diff,
+	})
+
+	diff = truncateDiff(diff, o.maxDiffLines)
+	color := o.useColor()
+	if o.wordDiff && !o.sideBySide {
+		diff = highlightWords(diff, color)
+	}
+	if color {
+		diff = colorizeDiff(diff)
+	}
 
-	errorTrace := strings.Join(assert.CallerInfo(), "\n\t\t\t")
+	errorTrace := strings.Join(callerInfo(), "\n\t\t\t")
 	msg := fmt.Sprintf("%s\n\tError Trace:\t%s\n\tError:%s\n",
 		getWhitespaceString(),
 		errorTrace,
@@ -60,15 +110,33 @@ func FailDiff(t TestingT, failureMessage, diff string, msgAndArgs ...interface{}
 		)
 	}
 
-	t.Errorf(msg)
+	t.Errorf("%s", msg)
 	return false
 }
 
 // Fail reports a failure through
 func Fail(t TestingT, failureMessage string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return fail(t, failureMessage, o, msgAndArgs...)
+}
+
+// fail reports a failure, according to o.
+func fail(t TestingT, failureMessage string, o *options, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	message := messageFromMsgAndArgs(msgAndArgs...)
+	reportFailure(t, FailureRecord{
+		Error:    failureMessage,
+		Message:  message,
+		Expected: o.reportExpected,
+		Actual:   o.reportActual,
+	})
 
-	errorTrace := strings.Join(assert.CallerInfo(), "\n\t\t\t")
+	errorTrace := strings.Join(callerInfo(), "\n\t\t\t")
 	if len(message) > 0 {
 		t.Errorf("%s\tError Trace:\t%s\n"+
 			"\tError:%s\n"+
@@ -93,128 +161,456 @@ func Fail(t TestingT, failureMessage string, msgAndArgs ...interface{}) bool {
 // var addRE = regexp.MustCompile("\\(0x[0-9a-f]{6,10}\\)")
 // var addRepl = "(0xXXXXXXXXXX)"
 
-func diff(expected, actual string) string {
+func diff(expected, actual string, o *options) string {
 	if !strings.HasSuffix(expected, "\n") {
 		expected = expected + "\n"
 	}
 	if !strings.HasSuffix(actual, "\n") {
 		actual = actual + "\n"
 	}
-	udiff := difflib.UnifiedDiff{
-		A:        strings.SplitAfter(expected, "\n"),
-		FromFile: "expected",
-		B:        strings.SplitAfter(actual, "\n"),
-		ToFile:   "actual",
-		Context:  2,
-	}
-	diff, err := difflib.GetUnifiedDiffString(udiff)
-	if err != nil {
-		panic("Error producing diff: " + err.Error())
+	a := strings.SplitAfter(expected, "\n")
+	b := strings.SplitAfter(actual, "\n")
+	groups := groupOpCodes(opCodes(a, b, o.diffAlgorithm), o.diffContext)
+	if o.sideBySide {
+		return sideBySideDiff(a, b, groups)
 	}
-	return diff
+	return unifiedDiff(a, b, groups)
+}
+
+// spewConfig is the configuration with which values are dumped by spew. Keys
+// are sorted, and methods are not called, so that dumps are stable.
+var spewConfig = spew.ConfigState{
+	Indent:         "  ",
+	DisableMethods: true,
+	SortKeys:       true,
 }
 
-func interfaceDiff(expected, actual interface{}) string {
-	scs := spew.ConfigState{
-		Indent:         "  ",
-		DisableMethods: true,
-		SortKeys:       true,
+// interfaceDumps returns the dumps of expected and actual which are diffed
+// when they differ.
+func interfaceDumps(expected, actual interface{}, o *options) (string, string) {
+	if o.collapseMaps || hasComparators() || o.compare.lenient() ||
+		containsTime(reflect.TypeOf(expected), map[reflect.Type]bool{}) || containsTime(reflect.TypeOf(actual), map[reflect.Type]bool{}) ||
+		hasCycle(reflect.ValueOf(expected)) || hasCycle(reflect.ValueOf(actual)) {
+		return structDumps(expected, actual, o)
 	}
-	expString := scs.Sdump(expected)
-	actString := scs.Sdump(actual)
+	expString := spewConfig.Sdump(expected)
+	actString := spewConfig.Sdump(actual)
 
 	// expString = capRE.ReplaceAllString(expString, capRepl)
 	// actString = capRE.ReplaceAllString(actString, capRepl)
 	// expString = addRE.ReplaceAllString(expString, addRepl)
 	// actString = addRE.ReplaceAllString(actString, addRepl)
 
-	return diff(expString, actString)
+	return expString, actString
 }
 
-// DeepEqual asserts that two objects are deeply equal.
+// failDiff reports a failure, including a diff of the expected and actual
+// strings.
+func failDiff(t TestingT, failureMessage, expected, actual string, o *options, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	report := *o
+	report.reportExpected, report.reportActual = expected, actual
+	o = &report
+	d := diff(expected, actual, o)
+	if o.diffDir != "" && len(d) > o.diffThreshold {
+		path, err := writeDiffFiles(t, o.diffDir, expected, actual, d)
+		if err != nil {
+			return reportDiff(t, fmt.Sprintf("%s\nFailed to write diff files: %s", failureMessage, err), d, o, msgAndArgs...)
+		}
+		return fail(t, fmt.Sprintf("%s\nFull diff written to %s", failureMessage, path), o, msgAndArgs...)
+	}
+	return reportDiff(t, failureMessage, d, o, msgAndArgs...)
+}
+
+// failInterfaceDiff reports a failure, including a diff of the dumps of
+// expected and actual.
+func failInterfaceDiff(t TestingT, failureMessage string, expected, actual interface{}, o *options, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if o.breadcrumbs {
+		return reportDiff(t, failureMessage, breadcrumbDiff(differences(expected, actual, &o.compare), &o.compare), o, msgAndArgs...)
+	}
+	expString, actString := interfaceDumps(expected, actual, o)
+	return failDiff(t, failureMessage, expString, actString, o, msgAndArgs...)
+}
+
+// DeepEqual asserts that two objects are deeply equal. Values of any type for
+// which a Comparator has been registered with RegisterComparator are compared
+// with that comparator. The WithCollapsedMaps option may be passed to limit the
+// diff of large maps to the changed entries, or WithBreadcrumbContext to show
+// each change beneath the path of fields which contain it.
 func DeepEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
-	if reflect.DeepEqual(expected, actual) {
-		return true
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
-	return FailDiff(t, "Structs differ", interfaceDiff(expected, actual), msgAndArgs...)
+	return DeepEqualWithOptions(t, expected, actual, Options{}, msgAndArgs...)
 }
 
 // DeepEqual asserts that two objects are deeply equal.
 func (a *Assertions) DeepEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
-	return DeepEqual(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // DeepEqualJSON marshals the expected and actual interfaces to JSON, then
 // unmarshals before doing a reflect.DeepEqual check on them. If they are
 // unequal, a diff of their respective JSON representations is produced as
-// output.
+// output. The WithIgnoredJSONPaths option excludes volatile values, such as
+// generated IDs or timestamps, from the comparison.
 func DeepEqualJSON(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
-	expectedJSON := marshalJSON(t, expected, msgAndArgs...)
-	actualJSON := marshalJSON(t, actual, msgAndArgs...)
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedJSON, ok := marshalJSON(t, "expected", expected, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	actualJSON, ok := marshalJSON(t, "actual", actual, msgAndArgs...)
+	if !ok {
+		return false
+	}
 	var e, a interface{}
-	json.Unmarshal(expectedJSON, &e)
-	json.Unmarshal(actualJSON, &a)
+	if !unmarshalJSON(t, "expected", expectedJSON, &e, msgAndArgs...) {
+		return false
+	}
+	if !unmarshalJSON(t, "actual", actualJSON, &a, msgAndArgs...) {
+		return false
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	if len(o.ignoredJSONPaths) > 0 {
+		var err error
+		if e, err = removeJSONPaths(e, o.ignoredJSONPaths); err != nil {
+			return Fail(t, err.Error(), msgAndArgs...)
+		}
+		if a, err = removeJSONPaths(a, o.ignoredJSONPaths); err != nil {
+			return Fail(t, err.Error(), msgAndArgs...)
+		}
+	}
+	if o.jsonNumberTolerance > 0 {
+		a = alignJSONNumbers(e, a, o.jsonNumberTolerance)
+	}
+	if o.compare.NilEqualsEmpty {
+		a = alignJSONNulls(e, a)
+	}
 	if reflect.DeepEqual(e, a) {
 		return true
 	}
-	return FailDiff(t, "JSON representations differ", diff(string(expectedJSON), string(actualJSON)), msgAndArgs...)
+	msg := "JSON representations differ"
+	if o.jsonSummary {
+		msg += "\n" + jsonSummary(e, a)
+	}
+	if o.jsonPatch {
+		msg += "\nJSON Patch:\n" + jsonPatch(e, a)
+	}
+	// Both sides are re-rendered from their unmarshaled values, so object keys
+	// appear in sorted order, regardless of struct field order.
+	return failDiff(t, msg, indentJSON(e), indentJSON(a), o, msgAndArgs...)
 }
 
 // DeepEqualJSON marshals the expected and actual interfaces to JSON, then
 // unmarshals before doing a reflect.DeepEqual check on them. If they are
 // unequal, a diff of their respective JSON representations is produced as
-// output.
+// output. The WithIgnoredJSONPaths option excludes volatile values, such as
+// generated IDs or timestamps, from the comparison.
 func (a *Assertions) DeepEqualJSON(expected, actual interface{}, msgAndArgs ...interface{}) bool {
-	return DeepEqualJSON(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSON(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// DeepEqualJSONWithPatch behaves like DeepEqualJSON, but on failure also
+// reports the RFC 6902 JSON Patch which would transform expected into actual.
+func DeepEqualJSONWithPatch(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSON(t, expected, actual, append(msgAndArgs, WithJSONPatch())...)
 }
 
-func marshalJSON(t TestingT, i interface{}, msgAndArgs ...interface{}) []byte {
+// DeepEqualJSONWithPatch behaves like DeepEqualJSON, but on failure also
+// reports the RFC 6902 JSON Patch which would transform expected into actual.
+func (a *Assertions) DeepEqualJSONWithPatch(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSONWithPatch(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// DeepEqualJSONSummary behaves like DeepEqualJSON, but on failure also
+// reports a plain-English summary of the changes, such as "3 fields changed,
+// 1 added, 2 removed", followed by one line per change, before the full diff.
+func DeepEqualJSONSummary(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSON(t, expected, actual, append(msgAndArgs, Option(func(o *options) {
+		o.jsonSummary = true
+	}))...)
+}
+
+// DeepEqualJSONSummary behaves like DeepEqualJSON, but on failure also
+// reports a plain-English summary of the changes, such as "3 fields changed,
+// 1 added, 2 removed", followed by one line per change, before the full diff.
+func (a *Assertions) DeepEqualJSONSummary(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSONSummary(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// marshalJSON marshals i, which is described by name in any failure message,
+// to indented JSON. If marshaling fails, the failure is reported and false is
+// returned.
+func marshalJSON(t TestingT, name string, i interface{}, msgAndArgs ...interface{}) ([]byte, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	output, err := json.MarshalIndent(i, "", "    ")
 	if err != nil {
-		Fail(t, fmt.Sprintf("Error marshaling JSON: %s\n", err), msgAndArgs...)
+		return nil, Fail(t, fmt.Sprintf("Error marshaling %s JSON: %s\n", name, err), msgAndArgs...)
+	}
+	return output, true
+}
+
+// unmarshalJSON unmarshals data into v. If data is not valid JSON, the
+// failure is reported, naming the side described by name and including the
+// offending JSON, and false is returned.
+func unmarshalJSON(t TestingT, name string, data []byte, v *interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
-	return output
+	if err := json.Unmarshal(data, v); err != nil {
+		return Fail(t, fmt.Sprintf("Error unmarshaling %s JSON: %s\n%s", name, err, data), msgAndArgs...)
+	}
+	return true
 }
 
 // MarshalsToJSON asserts that the actual interface{} marshals to the expected
 // JSON.
 func MarshalsToJSON(t TestingT, expected []byte, actual interface{}, msgAndArgs ...interface{}) bool {
-	actualJSON := marshalJSON(t, actual, msgAndArgs...)
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	actualJSON, ok := marshalJSON(t, "actual", actual, msgAndArgs...)
+	if !ok {
+		return false
+	}
 	var e, a interface{}
-	if err := json.Unmarshal(expected, &e); err != nil {
-		return Fail(t, "Error unmarshaling expected JSON string", msgAndArgs...)
+	if !unmarshalJSON(t, "expected", expected, &e, msgAndArgs...) {
+		return false
+	}
+	if !unmarshalJSON(t, "actual", actualJSON, &a, msgAndArgs...) {
+		return false
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	if o.jsonNumberTolerance > 0 {
+		a = alignJSONNumbers(e, a, o.jsonNumberTolerance)
+	}
+	if o.compare.NilEqualsEmpty {
+		a = alignJSONNulls(e, a)
 	}
-	json.Unmarshal(actualJSON, &a)
 	if reflect.DeepEqual(e, a) {
 		return true
 	}
-	return FailDiff(t, "JSON representations differ", diff(string(expected), string(actualJSON)), msgAndArgs...)
+	msg := "JSON representations differ"
+	if o.jsonPatch {
+		msg += "\nJSON Patch:\n" + jsonPatch(e, a)
+	}
+	return failDiff(t, msg, indentJSON(e), indentJSON(a), o, msgAndArgs...)
 }
 
 // MarshalsToJSON asserts that the actual interface{} marshals to the expected
 // JSON.
 func (a *Assertions) MarshalsToJSON(expected []byte, actual interface{}, msgAndArgs ...interface{}) bool {
-	return MarshalsToJSON(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MarshalsToJSON(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// UnmarshalsFromJSON asserts that data unmarshals into a value of the same
+// type as expected, which deeply equals expected. It is the inverse of
+// MarshalsToJSON.
+func UnmarshalsFromJSON(t TestingT, data []byte, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if expected == nil {
+		return Fail(t, "Expected value must not be nil", msgAndArgs...)
+	}
+	v := reflect.New(reflect.TypeOf(expected))
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return Fail(t, fmt.Sprintf("Error unmarshaling JSON: %s\n%s", err, data), msgAndArgs...)
+	}
+	return DeepEqual(t, expected, v.Elem().Interface(), msgAndArgs...)
+}
+
+// UnmarshalsFromJSON asserts that data unmarshals into a value of the same
+// type as expected, which deeply equals expected. It is the inverse of
+// MarshalsToJSON.
+func (a *Assertions) UnmarshalsFromJSON(data []byte, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return UnmarshalsFromJSON(a.t, data, expected, a.withOptions(msgAndArgs)...)
+}
+
+// JSONRoundTrip asserts that marshaling value to JSON, then unmarshaling the
+// result into a new value of the same type, yields a value which deeply
+// equals the original. This catches lossy MarshalJSON and UnmarshalJSON
+// implementations.
+func JSONRoundTrip(t TestingT, value interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if value == nil {
+		return Fail(t, "Value must not be nil", msgAndArgs...)
+	}
+	data, ok := marshalJSON(t, "value", value, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	v := reflect.New(reflect.TypeOf(value))
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return Fail(t, fmt.Sprintf("Error unmarshaling JSON: %s\n%s", err, data), msgAndArgs...)
+	}
+	after := v.Elem().Interface()
+	if len(differences(value, after, nil)) == 0 {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failInterfaceDiff(t, "Value changed during JSON round trip\nJSON:\n"+string(data), value, after, o, msgAndArgs...)
+}
+
+// JSONRoundTrip asserts that marshaling value to JSON, then unmarshaling the
+// result into a new value of the same type, yields a value which deeply
+// equals the original. This catches lossy MarshalJSON and UnmarshalJSON
+// implementations.
+func (a *Assertions) JSONRoundTrip(value interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONRoundTrip(a.t, value, a.withOptions(msgAndArgs)...)
+}
+
+// DeepEqualJSON5 asserts that the actual interface{} marshals to JSON
+// equivalent to the expected JSON5 document. JSON5 permits comments, trailing
+// commas, unquoted keys and other conveniences, which makes it well suited to
+// annotated fixtures.
+func DeepEqualJSON5(t TestingT, expected []byte, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	e, err := parseJSON5(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected JSON5: %s", err), msgAndArgs...)
+	}
+	expectedJSON, ok := marshalJSON(t, "expected", e, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	return MarshalsToJSON(t, expectedJSON, actual, msgAndArgs...)
+}
+
+// DeepEqualJSON5 asserts that the actual interface{} marshals to JSON
+// equivalent to the expected JSON5 document. JSON5 permits comments, trailing
+// commas, unquoted keys and other conveniences, which makes it well suited to
+// annotated fixtures.
+func (a *Assertions) DeepEqualJSON5(expected []byte, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSON5(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // LinesEqual asserts that the two strings are equal, or shows a line-by-line
-// diff of their differences.
+// diff of their differences. The diff algorithm may be selected with the
+// WithDiffAlgorithm option.
 func LinesEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if expected == actual {
 		return true
 	}
-	return FailDiff(t, "Strings differ", diff(expected, actual), msgAndArgs...)
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "Strings differ", expected, actual, o, msgAndArgs...)
 }
 
 // LinesEqual asserts that the two strings are equal, or shows a line-by-line
 // diff of their differences.
 func (a *Assertions) LinesEqual(expected, actual string, msgAndArgs ...interface{}) bool {
-	return LinesEqual(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return LinesEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // HTMLEqual asserts that the two arguments represent equivalent HTML. Accepts
-// strings, byte arrays, *html.Node objects, or goquery selection.
+// strings, byte arrays, *html.Node objects, or goquery selection. Both
+// documents are normalized before comparison, so that attribute order, the
+// order of class names, and insignificant whitespace do not matter. Comments
+// may be ignored with the WithIgnoreHTMLComments option, and the WithXHTML
+// option requires both documents to be well-formed XML. Use HTMLEqualStrict
+// to compare the documents exactly as parsed.
 func HTMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return htmlEqual(t, expected, actual, false, msgAndArgs...)
+}
+
+// HTMLEqual asserts that the two arguments represent equivalent HTML. Accepts
+// strings, byte arrays, *html.Node objects, or goquery selection. Both
+// documents are normalized before comparison, so that attribute order, the
+// order of class names, and insignificant whitespace do not matter. Comments
+// may be ignored with the WithIgnoreHTMLComments option, and the WithXHTML
+// option requires both documents to be well-formed XML. Use HTMLEqualStrict
+// to compare the documents exactly as parsed.
+func (a *Assertions) HTMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// HTMLEqualStrict asserts that the two arguments parse to identical HTML
+// trees, including attribute order and all whitespace. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The
+// WithIgnoreHTMLWhitespace and WithIgnoreHTMLAttributeOrder options relax the
+// comparison of whitespace and attributes respectively.
+func HTMLEqualStrict(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return htmlEqual(t, expected, actual, true, msgAndArgs...)
+}
+
+// HTMLEqualStrict asserts that the two arguments parse to identical HTML
+// trees, including attribute order and all whitespace. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The
+// WithIgnoreHTMLWhitespace and WithIgnoreHTMLAttributeOrder options relax the
+// comparison of whitespace and attributes respectively.
+func (a *Assertions) HTMLEqualStrict(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLEqualStrict(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// htmlEqual compares two HTML documents. Unless strict is true, both are
+// fully normalized before comparison; otherwise only the normalizations
+// selected by options are applied.
+func htmlEqual(t TestingT, expected, actual interface{}, strict bool, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	expDoc, err := toHTMLNode(expected)
 	if err != nil {
 		t.Errorf("invalid expected document: %s", err)
@@ -225,12 +621,29 @@ func HTMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface
 		t.Errorf("invalid actual document: %s", err)
 		t.FailNow()
 	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	if o.xhtml {
+		if err := checkXHTML(expected); err != nil {
+			return Fail(t, fmt.Sprintf("Expected document is not well-formed XHTML: %s", err), msgAndArgs...)
+		}
+		if err := checkXHTML(actual); err != nil {
+			return Fail(t, fmt.Sprintf("Actual document is not well-formed XHTML: %s", err), msgAndArgs...)
+		}
+	}
+	norm := o.htmlNormalization(strict)
+	normalized := norm != htmlNormalization{}
+	if normalized {
+		expDoc, actDoc = normalizeHTML(expDoc, norm), normalizeHTML(actDoc, norm)
+	}
 	if !reflect.DeepEqual(expDoc, actDoc) {
+		if normalized {
+			return failDiff(t, "HTML differs", renderHTMLIndented(expDoc), renderHTMLIndented(actDoc), o, msgAndArgs...)
+		}
 		expBuf := new(bytes.Buffer)
 		html.Render(expBuf, expDoc)
 		actBuf := new(bytes.Buffer)
 		html.Render(actBuf, actDoc)
-		return FailDiff(t, "HTML differs", diff(expBuf.String(), actBuf.String()), msgAndArgs...)
+		return failDiff(t, "HTML differs", expBuf.String(), actBuf.String(), o, msgAndArgs...)
 	}
 	return true
 }
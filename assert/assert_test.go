@@ -0,0 +1,113 @@
+package assert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// failingMarshaler implements json.Marshaler, always returning an error.
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("marshaling refused")
+}
+
+func TestDeepEqualJSON(t *testing.T) {
+	var nilMap map[string]int
+	tests := []struct {
+		name     string
+		expected interface{}
+		actual   interface{}
+		want     string
+	}{
+		{name: "different keys", expected: map[string]int{"a": 1}, actual: struct{ A int }{1}, want: "JSON representations differ\n-    \"a\": 1\n+    \"A\": 1"},
+		{name: "equal after marshaling", expected: map[string]int{"A": 1}, actual: struct{ A int }{1}},
+		{
+			name:     "malformed expected JSON",
+			expected: json.RawMessage(`{"a": `),
+			actual:   map[string]int{"a": 1},
+			want:     "Error marshaling expected JSON",
+		},
+		{
+			name:     "MarshalJSON error",
+			expected: map[string]int{"a": 1},
+			actual:   failingMarshaler{},
+			want:     "Error marshaling actual JSON\nmarshaling refused",
+		},
+		{name: "both null", expected: nil, actual: nilMap},
+		{
+			name:     "null actual",
+			expected: map[string]int{},
+			actual:   nilMap,
+			want:     "JSON representations differ\n-{}\n+null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualJSON(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualJSON returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestMarshalsToJSON(t *testing.T) {
+	var nilPtr *struct{ A int }
+	tests := []struct {
+		name     string
+		expected string
+		actual   interface{}
+		want     string
+	}{
+		{name: "equal", expected: `{"A": 1}`, actual: struct{ A int }{1}},
+		{
+			name:     "different",
+			expected: `{"A": 1}`,
+			actual:   struct{ A int }{2},
+			want:     "JSON representations differ\n-    \"A\": 1\n+    \"A\": 2",
+		},
+		{
+			name:     "malformed expected JSON",
+			expected: `{"A": `,
+			actual:   struct{ A int }{1},
+			want:     "Error unmarshaling expected JSON: unexpected end of JSON input\n{\"A\": ",
+		},
+		{
+			name:     "MarshalJSON error",
+			expected: `{}`,
+			actual:   failingMarshaler{},
+			want:     "Error marshaling actual JSON\nmarshaling refused",
+		},
+		{name: "null", expected: `null`, actual: nilPtr},
+		{
+			name:     "null where object expected",
+			expected: `{"A": 1}`,
+			actual:   nilPtr,
+			want:     "JSON representations differ\n+null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := MarshalsToJSON(m, []byte(tt.expected), tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("MarshalsToJSON returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
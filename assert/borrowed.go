@@ -63,3 +63,50 @@ func indentMessageLines(message string, tabs int) string {
 
 	return outBuf.String()
 }
+
+// modulePrefix is the import path prefix of this module's packages, whose
+// frames are omitted from error traces.
+const modulePrefix = "github.com/flimzy/testify/"
+
+// callerInfo returns the file:line of each frame of the call stack, from the
+// caller of the assertion up to the test function. Frames within this
+// module's own packages, other than those in test files, are omitted, so the
+// trace begins at user code.
+func callerInfo() []string {
+	var callers []string
+	for i := 0; ; i++ {
+		pc, file, line, ok := runtime.Caller(i)
+		if !ok {
+			// The breaks below failed to terminate the loop, and we ran off the
+			// end of the call stack.
+			break
+		}
+
+		// This is a huge edge case, but it will panic if this is the case, see
+		// https://github.com/stretchr/testify/issues/180
+		if file == "<autogenerated>" {
+			break
+		}
+
+		f := runtime.FuncForPC(pc)
+		if f == nil {
+			break
+		}
+		name := f.Name()
+
+		// testing.tRunner is the standard library function that calls tests.
+		// Subtests are called directly by tRunner, without going through the
+		// Test/Benchmark/Example function that contains the t.Run calls, so
+		// with subtests we should break when we hit tRunner, without adding it
+		// to the list of callers.
+		if name == "testing.tRunner" {
+			break
+		}
+
+		if strings.HasPrefix(name, modulePrefix) && !strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		callers = append(callers, fmt.Sprintf("%s:%d", file, line))
+	}
+	return callers
+}
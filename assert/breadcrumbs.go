@@ -0,0 +1,142 @@
+package assert
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithBreadcrumbContext causes struct diffs to be rendered as a list of the
+// changed values, each shown beneath the chain of enclosing field names, map
+// keys and indexes which lead to it, rather than as a unified diff of the
+// full dumps. This shows where in the structure each change lies, where the
+// adjacent lines of a unified diff may be unrelated fields.
+func WithBreadcrumbContext() Option {
+	return func(o *options) {
+		o.breadcrumbs = true
+	}
+}
+
+// breadcrumbDiff renders diffs with each change preceded by its enclosing path
+// segments. Segments shared with the previous change are not repeated.
+func breadcrumbDiff(diffs []difference, opts *Options) string {
+	type crumbs struct {
+		segments []string
+		diff     difference
+	}
+	all := make([]crumbs, len(diffs))
+	for i, d := range diffs {
+		all[i] = crumbs{segments: splitPath(d.path), diff: d}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return segmentsLess(all[i].segments, all[j].segments)
+	})
+	buf := &bytes.Buffer{}
+	var prev []string
+	for _, c := range all {
+		parents, leaf := c.segments, ""
+		if len(parents) > 0 {
+			parents, leaf = parents[:len(parents)-1], parents[len(parents)-1]
+		}
+		common := 0
+		for common < len(parents) && common < len(prev) && parents[common] == prev[common] {
+			common++
+		}
+		for i := common; i < len(parents); i++ {
+			buf.WriteString(" " + strings.Repeat("  ", i) + parents[i] + "\n")
+		}
+		prev = parents
+		depth := len(parents)
+		writeBreadcrumbValue(buf, "-", depth, leaf, c.diff.expected, opts)
+		writeBreadcrumbValue(buf, "+", depth, leaf, c.diff.actual, opts)
+	}
+	return buf.String()
+}
+
+func writeBreadcrumbValue(buf *bytes.Buffer, prefix string, depth int, leaf string, v reflect.Value, opts *Options) {
+	value := "<missing>"
+	if v.IsValid() {
+		d := &dumper{compare: opts}
+		value = d.render(v, depth)
+	}
+	if leaf != "" {
+		value = leaf + ": " + value
+	}
+	indent := strings.Repeat("  ", depth)
+	for i, line := range strings.Split(value, "\n") {
+		if i == 0 {
+			line = indent + line
+		}
+		buf.WriteString(prefix + line + "\n")
+	}
+}
+
+// splitPath splits a path, as produced by the comparer, into its field name,
+// map key and index segments.
+func splitPath(path string) []string {
+	var segments []string
+	var start, depth int
+	var quote rune
+	var escaped bool
+	for i, r := range path {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			switch r {
+			case '\\':
+				escaped = true
+			case quote:
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			if depth > 0 {
+				quote = r
+			}
+		case r == '[':
+			if depth == 0 && i > start {
+				segments = append(segments, path[start:i])
+				start = i
+			}
+			depth++
+		case r == ']':
+			depth--
+		case r == '.' && depth == 0:
+			if i > start {
+				segments = append(segments, path[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(path) {
+		segments = append(segments, path[start:])
+	}
+	return segments
+}
+
+// segmentsLess orders paths segment by segment, comparing indexes
+// numerically.
+func segmentsLess(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		if x, ok := pathIndex(a[i]); ok {
+			if y, ok := pathIndex(b[i]); ok {
+				return x < y
+			}
+		}
+		return a[i] < b[i]
+	}
+	return len(a) < len(b)
+}
+
+func pathIndex(segment string) (int, bool) {
+	if !strings.HasPrefix(segment, "[") || !strings.HasSuffix(segment, "]") {
+		return 0, false
+	}
+	i, err := strconv.Atoi(segment[1 : len(segment)-1])
+	return i, err == nil
+}
@@ -0,0 +1,120 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type crumbAddress struct {
+	City string
+	Zip  string
+}
+
+type crumbUser struct {
+	Name      string
+	Addresses []crumbAddress
+	Labels    map[string]string
+}
+
+type crumbOrg struct {
+	ID    int
+	Users []crumbUser
+}
+
+func TestBreadcrumbDiff(t *testing.T) {
+	org := func(zip, env, city string) crumbOrg {
+		return crumbOrg{ID: 1, Users: []crumbUser{
+			{Name: "a", Addresses: []crumbAddress{{City: "Oslo", Zip: zip}}, Labels: map[string]string{"env": env}},
+			{Name: "b", Addresses: []crumbAddress{{City: "Rome", Zip: "00100"}, {City: city, Zip: "3000"}}},
+		}}
+	}
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		want             string
+	}{
+		{
+			name:     "top level",
+			expected: crumbAddress{City: "Oslo"},
+			actual:   crumbAddress{City: "Rome"},
+			want: `-City: (string) (len=4) "Oslo"
++City: (string) (len=4) "Rome"
+`,
+		},
+		{
+			name:     "nested",
+			expected: org("0150", "prod", "Bern"),
+			actual:   org("0151", "prod", "Bern"),
+			want: ` Users
+   [0]
+     Addresses
+       [0]
+-        Zip: (string) (len=4) "0150"
++        Zip: (string) (len=4) "0151"
+`,
+		},
+		{
+			name:     "shared segments are not repeated",
+			expected: org("0150", "prod", "Bern"),
+			actual:   org("0151", "dev", "Basel"),
+			want: ` Users
+   [0]
+     Addresses
+       [0]
+-        Zip: (string) (len=4) "0150"
++        Zip: (string) (len=4) "0151"
+     Labels
+-      ["env"]: (string) (len=4) "prod"
++      ["env"]: (string) (len=3) "dev"
+   [1]
+     Addresses
+       [1]
+-        City: (string) (len=4) "Bern"
++        City: (string) (len=5) "Basel"
+`,
+		},
+		{
+			name:     "missing key",
+			expected: crumbUser{Labels: map[string]string{"env": "prod", "team": "x"}},
+			actual:   crumbUser{Labels: map[string]string{"team": "x"}},
+			want: ` Labels
+-  ["env"]: (string) (len=4) "prod"
++  ["env"]: <missing>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Options{}
+			if got := breadcrumbDiff(differences(tt.expected, tt.actual, opts), opts); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithBreadcrumbContext(t *testing.T) {
+	m := &mockT{}
+	expected := crumbOrg{Users: []crumbUser{{Addresses: []crumbAddress{{City: "Oslo"}}}}}
+	actual := crumbOrg{Users: []crumbUser{{Addresses: []crumbAddress{{City: "Rome"}}}}}
+	if DeepEqual(m, expected, actual, WithBreadcrumbContext()) {
+		t.Fatal("DeepEqual returned true")
+	}
+	want := []string{
+		"Structs differ",
+		"\t Users\n",
+		"\t   [0]\n",
+		"\t     Addresses\n",
+		"\t       [0]\n",
+		"\t-        City: (string) (len=4) \"Oslo\"\n",
+		"\t+        City: (string) (len=4) \"Rome\"\n",
+	}
+	out := m.output()
+	for _, line := range want {
+		i := strings.Index(out, line)
+		if i < 0 {
+			t.Fatalf("failure does not contain %q, in order:\n%s", line, m.output())
+		}
+		out = out[i+len(line):]
+	}
+}
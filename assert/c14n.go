@@ -0,0 +1,311 @@
+package assert
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// xmlNamespace is the namespace bound to the reserved xml prefix.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// CanonicalXMLEqual asserts that the two arguments have identical W3C
+// Exclusive XML Canonicalization (without comments) forms. Accepts strings,
+// byte arrays, or io.Readers. Canonicalization discards the XML declaration,
+// DTD and comments, orders attributes, renders each namespace declaration
+// only on the outermost element which uses it, and normalizes quoting, empty
+// elements and character references, so that documents which differ only in
+// these respects are equal. Unlike XMLEqual, whitespace and namespace
+// prefixes are significant, as they are to an XML signature, which a change
+// of prefix invalidates; use XMLEqual where prefixes do not matter. On
+// failure, a diff of the canonical forms is shown, one tag or quoted text node
+// per line, noting if the documents would be equal to XMLEqual.
+func CanonicalXMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, err := canonicalXML(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid expected document: %s", err), msgAndArgs...)
+	}
+	act, err := canonicalXML(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid actual document: %s", err), msgAndArgs...)
+	}
+	if strings.Join(exp, "") == strings.Join(act, "") {
+		return true
+	}
+	msg := "Canonical XML differs"
+	if xmlEquivalent(expected, actual) {
+		msg += "; the documents differ only in namespace prefixes or whitespace, which XMLEqual ignores"
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, msg, c14nDiffLines(exp), c14nDiffLines(act), o, msgAndArgs...)
+}
+
+// CanonicalXMLEqual asserts that the two arguments have identical W3C
+// Exclusive XML Canonicalization (without comments) forms. Accepts strings,
+// byte arrays, or io.Readers. Canonicalization discards the XML declaration,
+// DTD and comments, orders attributes, renders each namespace declaration
+// only on the outermost element which uses it, and normalizes quoting, empty
+// elements and character references, so that documents which differ only in
+// these respects are equal. Unlike XMLEqual, whitespace and namespace
+// prefixes are significant, as they are to an XML signature, which a change
+// of prefix invalidates; use XMLEqual where prefixes do not matter. On
+// failure, a diff of the canonical forms is shown, one tag or quoted text node
+// per line, noting if the documents would be equal to XMLEqual.
+func (a *Assertions) CanonicalXMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CanonicalXMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// xmlEquivalent reports whether expected and actual, if strings or byte
+// arrays, are equal as compared by XMLEqual. Readers, which have already been
+// consumed, are never reported equivalent.
+func xmlEquivalent(expected, actual interface{}) bool {
+	for _, i := range []interface{}{expected, actual} {
+		if _, ok := i.(io.Reader); ok {
+			return false
+		}
+	}
+	exp, err := parseXML(expected)
+	if err != nil {
+		return false
+	}
+	act, err := parseXML(actual)
+	if err != nil {
+		return false
+	}
+	return exp.String() == act.String()
+}
+
+// c14nNode is a node of an XML document, as written, with namespace prefixes
+// unresolved.
+type c14nNode struct {
+	// name is empty for text and processing instruction nodes.
+	name  xml.Name
+	attrs []xml.Attr
+	// decls maps the prefixes declared on an element to their namespaces.
+	// The default namespace has the empty prefix.
+	decls    map[string]string
+	text     string
+	pi       *xml.ProcInst
+	children []*c14nNode
+}
+
+// canonicalXML returns the exclusive canonical form of the document i, split
+// into tokens: each tag, text node and processing instruction is a separate
+// element of the result.
+func canonicalXML(i interface{}) ([]string, error) {
+	r, err := xmlReader(i)
+	if err != nil {
+		return nil, err
+	}
+	dec := xml.NewDecoder(r)
+	root := &c14nNode{}
+	stack := []*c14nNode{root}
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &c14nNode{name: tok.Name, decls: map[string]string{}}
+			for _, attr := range tok.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					n.decls[""] = attr.Value
+				case attr.Name.Space == "xmlns":
+					n.decls[attr.Name.Local] = attr.Value
+				default:
+					n.attrs = append(n.attrs, attr)
+				}
+			}
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, errors.Errorf("unexpected end element </%s>", rawXMLName(tok.Name))
+			}
+			if top := stack[len(stack)-1]; top.name != tok.Name {
+				return nil, errors.Errorf("element <%s> closed by </%s>", rawXMLName(top.name), rawXMLName(tok.Name))
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			// Whitespace outside the document element is not significant.
+			if len(stack) == 1 {
+				continue
+			}
+			if last := len(parent.children) - 1; last >= 0 && parent.children[last].name.Local == "" && parent.children[last].pi == nil {
+				parent.children[last].text += string(tok)
+				continue
+			}
+			parent.children = append(parent.children, &c14nNode{text: string(tok)})
+		case xml.ProcInst:
+			if tok.Target == "xml" {
+				continue
+			}
+			pi := tok.Copy()
+			parent.children = append(parent.children, &c14nNode{pi: &pi})
+		}
+	}
+	if len(stack) > 1 {
+		return nil, errors.Errorf("element <%s> is not closed", rawXMLName(stack[len(stack)-1].name))
+	}
+	var tokens []string
+	seenRoot := false
+	for _, c := range root.children {
+		switch {
+		case c.pi != nil:
+			// Processing instructions outside the document element are
+			// separated from it by a line feed.
+			if seenRoot {
+				tokens = append(tokens, "\n")
+			}
+			tokens = append(tokens, c14nProcInst(c.pi))
+			if !seenRoot {
+				tokens = append(tokens, "\n")
+			}
+		case c.name.Local != "":
+			seenRoot = true
+			tokens = c.canonicalize(tokens, map[string]string{"xml": xmlNamespace}, map[string]string{})
+		}
+	}
+	if !seenRoot {
+		return nil, errors.New("no root element")
+	}
+	return tokens, nil
+}
+
+func rawXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// canonicalize appends the canonical tokens for n to tokens, given the
+// namespaces in scope from the ancestors of n in the document, and those
+// already declared by its ancestors in the canonical output.
+func (n *c14nNode) canonicalize(tokens []string, inScope, rendered map[string]string) []string {
+	switch {
+	case n.pi != nil:
+		return append(tokens, c14nProcInst(n.pi))
+	case n.name.Local == "":
+		return append(tokens, c14nText(n.text))
+	}
+	scope := make(map[string]string, len(inScope)+len(n.decls))
+	for prefix, uri := range inScope {
+		scope[prefix] = uri
+	}
+	for prefix, uri := range n.decls {
+		scope[prefix] = uri
+	}
+	// Only the namespaces visibly utilized by the element and its attributes
+	// are declared, and only if not already declared in the output.
+	used := map[string]bool{n.name.Space: true}
+	for _, attr := range n.attrs {
+		if attr.Name.Space != "" {
+			used[attr.Name.Space] = true
+		}
+	}
+	var prefixes []string
+	for prefix := range used {
+		if prefix == "xml" {
+			continue
+		}
+		uri := scope[prefix]
+		if current, ok := rendered[prefix]; ok && current == uri || !ok && prefix == "" && uri == "" {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	childRendered := rendered
+	if len(prefixes) > 0 {
+		childRendered = make(map[string]string, len(rendered)+len(prefixes))
+		for prefix, uri := range rendered {
+			childRendered[prefix] = uri
+		}
+	}
+	var tag strings.Builder
+	tag.WriteString("<" + rawXMLName(n.name))
+	for _, prefix := range prefixes {
+		childRendered[prefix] = scope[prefix]
+		if prefix == "" {
+			fmt.Fprintf(&tag, ` xmlns="%s"`, c14nAttrValue(scope[prefix]))
+			continue
+		}
+		fmt.Fprintf(&tag, ` xmlns:%s="%s"`, prefix, c14nAttrValue(scope[prefix]))
+	}
+	attrs := make([]xml.Attr, len(n.attrs))
+	copy(attrs, n.attrs)
+	attrURI := func(attr xml.Attr) string {
+		if attr.Name.Space == "" {
+			return ""
+		}
+		return scope[attr.Name.Space]
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		if ui, uj := attrURI(attrs[i]), attrURI(attrs[j]); ui != uj {
+			return ui < uj
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+	for _, attr := range attrs {
+		fmt.Fprintf(&tag, ` %s="%s"`, rawXMLName(attr.Name), c14nAttrValue(attr.Value))
+	}
+	tag.WriteString(">")
+	tokens = append(tokens, tag.String())
+	for _, c := range n.children {
+		tokens = c.canonicalize(tokens, scope, childRendered)
+	}
+	return append(tokens, "</"+rawXMLName(n.name)+">")
+}
+
+var (
+	c14nTextReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	c14nAttrReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;",
+		"\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func c14nText(s string) string {
+	return c14nTextReplacer.Replace(s)
+}
+
+func c14nAttrValue(s string) string {
+	return c14nAttrReplacer.Replace(s)
+}
+
+func c14nProcInst(pi *xml.ProcInst) string {
+	if len(pi.Inst) == 0 {
+		return "<?" + pi.Target + "?>"
+	}
+	return "<?" + pi.Target + " " + strings.TrimLeft(string(pi.Inst), " \t\r\n") + "?>"
+}
+
+// c14nDiffLines renders canonical tokens one per line, with text quoted so
+// that differences in whitespace are visible.
+func c14nDiffLines(tokens []string) string {
+	var buf strings.Builder
+	for _, tok := range tokens {
+		if strings.HasPrefix(tok, "<") {
+			buf.WriteString(tok)
+		} else {
+			fmt.Fprintf(&buf, "%q", tok)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
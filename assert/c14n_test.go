@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalXMLEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		want             string
+	}{
+		{
+			name:     "attribute order and quoting",
+			expected: `<a x="1" y='2'/>`,
+			actual:   `<?xml version="1.0"?><a y="2" x="1"></a>`,
+		},
+		{
+			name:     "unused namespace declaration",
+			expected: `<a xmlns:p="urn:p"><b/></a>`,
+			actual:   `<a><b/></a>`,
+		},
+		{
+			name:     "comments",
+			expected: `<a><!-- note -->x</a>`,
+			actual:   `<a>x</a>`,
+		},
+		{
+			name:     "character references",
+			expected: `<a>&#65;</a>`,
+			actual:   `<a>A</a>`,
+		},
+		{
+			name:     "namespace prefix",
+			expected: `<p:a xmlns:p="urn:x"/>`,
+			actual:   `<q:a xmlns:q="urn:x"/>`,
+			want:     "differ only in namespace prefixes or whitespace, which XMLEqual ignores",
+		},
+		{
+			name:     "whitespace",
+			expected: "<a><b/></a>",
+			actual:   "<a>\n  <b/>\n</a>",
+			want:     "differ only in namespace prefixes or whitespace, which XMLEqual ignores",
+		},
+		{
+			name:     "text",
+			expected: "<a>1</a>",
+			actual:   "<a>2</a>",
+			want:     "Canonical XML differs\n",
+		},
+		{
+			name:     "invalid",
+			expected: "<a>",
+			actual:   "<a/>",
+			want:     "invalid expected document",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := CanonicalXMLEqual(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("CanonicalXMLEqual returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CBOREqual asserts that the two CBOR-encoded byte slices decode to deeply
+// equal values. On failure, a diff of the values in CBOR diagnostic
+// notation (RFC 8949, section 8) is shown.
+func CBOREqual(t TestingT, expected, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var e, a interface{}
+	if err := cbor.Unmarshal(expected, &e); err != nil {
+		return Fail(t, fmt.Sprintf("Error decoding expected CBOR: %s", err), msgAndArgs...)
+	}
+	if err := cbor.Unmarshal(actual, &a); err != nil {
+		return Fail(t, fmt.Sprintf("Error decoding actual CBOR: %s", err), msgAndArgs...)
+	}
+	if reflect.DeepEqual(e, a) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "CBOR values differ", cborDiagnostic(e), cborDiagnostic(a), o, msgAndArgs...)
+}
+
+// CBOREqual asserts that the two CBOR-encoded byte slices decode to deeply
+// equal values. On failure, a diff of the values in CBOR diagnostic
+// notation (RFC 8949, section 8) is shown.
+func (a *Assertions) CBOREqual(expected, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CBOREqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// cborDiagnostic renders a decoded CBOR value in diagnostic notation, with
+// one array element or map entry per line and map entries sorted by key.
+func cborDiagnostic(v interface{}) string {
+	buf := &bytes.Buffer{}
+	writeCBORDiagnostic(buf, v, 0)
+	buf.WriteRune('\n')
+	return buf.String()
+}
+
+func writeCBORDiagnostic(buf *bytes.Buffer, v interface{}, depth int) {
+	indent := strings.Repeat("  ", depth+1)
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case uint64:
+		buf.WriteString(strconv.FormatUint(v, 10))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			buf.WriteString("NaN")
+		case math.IsInf(v, 1):
+			buf.WriteString("Infinity")
+		case math.IsInf(v, -1):
+			buf.WriteString("-Infinity")
+		default:
+			s := strconv.FormatFloat(v, 'g', -1, 64)
+			if !strings.ContainsAny(s, ".eEn") {
+				s += ".0"
+			}
+			buf.WriteString(s)
+		}
+	case string:
+		buf.WriteString(strconv.Quote(v))
+	case []byte:
+		fmt.Fprintf(buf, "h'%s'", hex.EncodeToString(v))
+	case cbor.ByteString:
+		fmt.Fprintf(buf, "h'%s'", hex.EncodeToString([]byte(v)))
+	case big.Int:
+		buf.WriteString(v.String())
+	case time.Time:
+		fmt.Fprintf(buf, "0(%q)", v.Format(time.RFC3339Nano))
+	case cbor.Tag:
+		fmt.Fprintf(buf, "%d(", v.Number)
+		writeCBORDiagnostic(buf, v.Content, depth)
+		buf.WriteRune(')')
+	case cbor.SimpleValue:
+		fmt.Fprintf(buf, "simple(%d)", v)
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString("[]")
+			return
+		}
+		buf.WriteString("[\n")
+		for i, elem := range v {
+			buf.WriteString(indent)
+			writeCBORDiagnostic(buf, elem, depth+1)
+			if i < len(v)-1 {
+				buf.WriteRune(',')
+			}
+			buf.WriteRune('\n')
+		}
+		buf.WriteString(strings.Repeat("  ", depth) + "]")
+	case map[interface{}]interface{}:
+		if len(v) == 0 {
+			buf.WriteString("{}")
+			return
+		}
+		entries := make([][2]string, 0, len(v))
+		for key, val := range v {
+			k, e := &bytes.Buffer{}, &bytes.Buffer{}
+			writeCBORDiagnostic(k, key, depth+1)
+			writeCBORDiagnostic(e, val, depth+1)
+			entries = append(entries, [2]string{k.String(), e.String()})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i][0] < entries[j][0]
+		})
+		buf.WriteString("{\n")
+		for i, entry := range entries {
+			buf.WriteString(indent + entry[0] + ": " + entry[1])
+			if i < len(entries)-1 {
+				buf.WriteRune(',')
+			}
+			buf.WriteRune('\n')
+		}
+		buf.WriteString(strings.Repeat("  ", depth) + "}")
+	default:
+		fmt.Fprintf(buf, "%v", v)
+	}
+}
@@ -0,0 +1,68 @@
+package assert
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+// WithCmp causes DeepEqual and DeepEqualWithOptions to compare values with
+// github.com/google/go-cmp, subject to opts, rather than with this package's
+// own comparison. Failures then show the output of cmp.Diff, which reports
+// each difference beneath the path to it. The DeepEqualWithOptions Options
+// are not applied by go-cmp; use the equivalent cmp.Options instead. WithCmp
+// may be given more than once, in which case all of the opts are used.
+func WithCmp(opts ...cmp.Option) Option {
+	return func(o *options) {
+		o.cmp = true
+		o.cmpOptions = append(o.cmpOptions, opts...)
+	}
+}
+
+// DeepEqualCmp asserts that two objects are deeply equal, as determined by
+// github.com/google/go-cmp. It is equivalent to DeepEqual with the WithCmp
+// option, which may also be passed to provide cmp.Options.
+func DeepEqualCmp(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqual(t, expected, actual, append([]interface{}{WithCmp()}, msgAndArgs...)...)
+}
+
+// DeepEqualCmp asserts that two objects are deeply equal, as determined by
+// github.com/google/go-cmp. It is equivalent to DeepEqual with the WithCmp
+// option, which may also be passed to provide cmp.Options.
+func (a *Assertions) DeepEqualCmp(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualCmp(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// cmpDeepEqual compares expected and actual with go-cmp, according to o.
+func cmpDeepEqual(t TestingT, expected, actual interface{}, o *options, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	diff, err := cmpDiff(expected, actual, o.cmpOptions)
+	if err != nil {
+		return fail(t, fmt.Sprintf("Unable to compare values: %s", err), o, msgAndArgs...)
+	}
+	if diff == "" {
+		return true
+	}
+	return reportDiff(t, "Structs differ (-expected +actual)", diff, o, msgAndArgs...)
+}
+
+// cmpDiff returns cmp.Diff of expected and actual. A panic from go-cmp, such
+// as for an unexported field which no option handles, is returned as an
+// error.
+func cmpDiff(expected, actual interface{}, opts []cmp.Option) (diff string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%v", r)
+		}
+	}()
+	return cmp.Diff(expected, actual, opts...), nil
+}
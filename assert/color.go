@@ -0,0 +1,79 @@
+package assert
+
+import (
+	"os"
+	"strings"
+)
+
+// colorEnv is the environment variable which, when set to any non-empty
+// value, enables colored diffs, as does the WithColoredDiff option.
+const colorEnv = "TESTIFY_COLOR"
+
+// ANSI escape sequences used to color diffs.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// WithColoredDiff causes diffs in failure messages to be colored with ANSI
+// escape sequences, with removals in red and additions in green. Colored
+// diffs may also be enabled for all assertions by setting the TESTIFY_COLOR
+// environment variable. Either way, color is only used when standard output
+// is a terminal, and never when the NO_COLOR environment variable is set.
+func WithColoredDiff() Option {
+	return func(o *options) {
+		o.color = true
+	}
+}
+
+// useColor reports whether diffs should be colored.
+func (o *options) useColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if !o.color && os.Getenv(colorEnv) == "" {
+		return false
+	}
+	return isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorizeDiff colors each line of the unified or side-by-side diff d
+// according to its prefix.
+func colorizeDiff(d string) string {
+	lines := strings.SplitAfter(d, "\n")
+	for i, line := range lines {
+		body := strings.TrimSuffix(line, "\n")
+		if body == "" {
+			continue
+		}
+		var color string
+		switch {
+		case strings.HasPrefix(body, "--- ") || strings.HasPrefix(body, "+++ "):
+			color = ansiBold
+		case strings.HasPrefix(body, "@@"):
+			color = ansiCyan
+		case strings.HasPrefix(body, "-"):
+			color = ansiRed
+		case strings.HasPrefix(body, "+"):
+			color = ansiGreen
+		case strings.HasPrefix(body, "!"):
+			color = ansiYellow
+		default:
+			continue
+		}
+		lines[i] = color + body + ansiReset + line[len(body):]
+	}
+	return strings.Join(lines, "")
+}
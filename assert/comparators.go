@@ -0,0 +1,88 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Comparator compares two values of the same type, returning whether they are
+// equal and, if they are not, a description of the difference.
+type Comparator func(a, b interface{}) (equal bool, diff string)
+
+var comparators = struct {
+	sync.RWMutex
+	m map[reflect.Type]Comparator
+}{m: make(map[reflect.Type]Comparator)}
+
+// RegisterComparator registers fn to compare values of type typ. DeepEqual
+// uses the registered comparator for any value of type typ which it
+// encounters, at any depth, in place of its usual comparison, and includes
+// the comparator's diff in its failure output. This centralizes the handling
+// of project-specific types, such as money or timestamps. Comparators are not
+// consulted for values held in unexported struct fields. Registering a nil
+// fn removes any comparator registered for typ.
+func RegisterComparator(typ reflect.Type, fn Comparator) {
+	comparators.Lock()
+	defer comparators.Unlock()
+	if fn == nil {
+		delete(comparators.m, typ)
+		return
+	}
+	comparators.m[typ] = fn
+}
+
+// WithComparer causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to compare values of type T with fn, which must be a
+// func(a, b T) bool, wherever they occur, in place of the usual comparison.
+// This allows types such as time.Time or decimal.Decimal to be compared
+// semantically, rather than field by field. It takes precedence over any
+// comparator registered for T with RegisterComparator. WithComparer panics
+// if fn is not of the required form.
+func WithComparer(fn interface{}) Option {
+	typ := reflect.TypeOf(fn)
+	if typ == nil || typ.Kind() != reflect.Func || typ.NumIn() != 2 || typ.In(0) != typ.In(1) || typ.IsVariadic() ||
+		typ.NumOut() != 1 || typ.Out(0).Kind() != reflect.Bool {
+		panic(fmt.Sprintf("WithComparer: %T is not a func(a, b T) bool", fn))
+	}
+	arg := func(i interface{}) reflect.Value {
+		if i == nil {
+			return reflect.Zero(typ.In(0))
+		}
+		return reflect.ValueOf(i)
+	}
+	comparator := func(a, b interface{}) (bool, string) {
+		return reflect.ValueOf(fn).Call([]reflect.Value{arg(a), arg(b)})[0].Bool(), ""
+	}
+	return func(o *options) {
+		o.compare = o.compare.merge(Options{
+			Comparers: map[reflect.Type]Comparator{typ.In(0): comparator},
+		})
+	}
+}
+
+func hasComparators() bool {
+	comparators.RLock()
+	defer comparators.RUnlock()
+	return len(comparators.m) > 0
+}
+
+// compareWithComparator compares e and a with the comparator in opts, or
+// otherwise the comparator registered, for their type. ok is false if no
+// comparator applies.
+func compareWithComparator(e, a reflect.Value, opts *Options) (equal bool, diff string, ok bool) {
+	if !e.CanInterface() || !a.CanInterface() {
+		return false, "", false
+	}
+	fn := opts.Comparers[e.Type()]
+	if fn == nil {
+		comparators.RLock()
+		fn = comparators.m[e.Type()]
+		comparators.RUnlock()
+	}
+	if fn == nil {
+		return false, "", false
+	}
+	equal, diff = fn(e.Interface(), a.Interface())
+	return equal, diff, true
+}
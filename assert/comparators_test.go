@@ -0,0 +1,111 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// money is an amount with a variable number of decimal places, so that equal
+// amounts need not be equal structs.
+type money struct {
+	cents int64
+	scale int
+}
+
+func (m money) normalized() int64 {
+	c := m.cents
+	for i := m.scale; i < 4; i++ {
+		c *= 10
+	}
+	return c
+}
+
+func compareMoney(a, b interface{}) (bool, string) {
+	x, y := a.(money).normalized(), b.(money).normalized()
+	if x == y {
+		return true, ""
+	}
+	return false, fmt.Sprintf("%d != %d", x, y)
+}
+
+type order struct {
+	ID     int
+	Price  money
+	Lines  []money
+	Totals map[string]*money
+	tax    money
+}
+
+func TestRegisterComparator(t *testing.T) {
+	RegisterComparator(reflect.TypeOf(money{}), compareMoney)
+	defer RegisterComparator(reflect.TypeOf(money{}), nil)
+
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		opts             []interface{}
+		want             string
+	}{
+		{
+			name:     "top level",
+			expected: money{100, 2},
+			actual:   money{1000, 3},
+		},
+		{
+			name:     "nested",
+			expected: order{ID: 1, Price: money{100, 2}, Lines: []money{{1, 0}}, Totals: map[string]*money{"net": {5, 1}}},
+			actual:   order{ID: 1, Price: money{1000, 3}, Lines: []money{{10, 1}}, Totals: map[string]*money{"net": {50, 2}}},
+		},
+		{
+			name:     "custom diff reported",
+			expected: order{ID: 1, Price: money{100, 2}},
+			actual:   order{ID: 1, Price: money{1001, 3}},
+			want:     "Price: 10000 != 10010",
+		},
+		{
+			name:     "custom diff reported in a slice",
+			expected: order{Lines: []money{{1, 0}, {2, 0}}},
+			actual:   order{Lines: []money{{1, 0}, {3, 0}}},
+			want:     "Lines[1]: 20000 != 30000",
+		},
+		{
+			name:     "not consulted for unexported fields",
+			expected: order{tax: money{1, 0}},
+			actual:   order{tax: money{10, 1}},
+			want:     "Structs differ",
+		},
+		{
+			name:     "WithComparer takes precedence",
+			expected: money{100, 2},
+			actual:   money{1000, 3},
+			opts:     []interface{}{WithComparer(func(a, b money) bool { return a == b })},
+			want:     "differ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqual(m, tt.expected, tt.actual, tt.opts...)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqual returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
+
+func TestRegisterComparatorNil(t *testing.T) {
+	RegisterComparator(reflect.TypeOf(money{}), compareMoney)
+	RegisterComparator(reflect.TypeOf(money{}), nil)
+	if hasComparators() {
+		t.Fatal("comparator still registered")
+	}
+	m := &mockT{}
+	if DeepEqual(m, money{100, 2}, money{1000, 3}) {
+		t.Error("DeepEqual used the removed comparator")
+	}
+}
@@ -0,0 +1,229 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// difference describes a single point at which two values differ. Either
+// value may be invalid, if the element exists on only one side.
+type difference struct {
+	path             string
+	expected, actual reflect.Value
+	// detail is the diff reported by a registered Comparator, if any.
+	detail string
+}
+
+type visit struct {
+	expected, actual uintptr
+	typ              reflect.Type
+}
+
+// comparer walks two values in parallel, with the same semantics as
+// reflect.DeepEqual, recording each point at which they differ.
+type comparer struct {
+	visited map[visit]bool
+	diffs   []difference
+	opts    *Options
+}
+
+// differences returns the leaf-level differences between expected and
+// actual, subject to opts, which may be nil. If the values are deeply equal,
+// the result is empty.
+func differences(expected, actual interface{}, opts *Options) []difference {
+	c := newComparer(opts)
+	c.compare(reflect.ValueOf(expected), reflect.ValueOf(actual), "")
+	return c.diffs
+}
+
+// valuesEqual reports whether e and a are deeply equal, subject to opts,
+// which may be nil.
+func valuesEqual(e, a reflect.Value, opts *Options) bool {
+	c := newComparer(opts)
+	c.compare(e, a, "")
+	return len(c.diffs) == 0
+}
+
+func newComparer(opts *Options) *comparer {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return &comparer{visited: make(map[visit]bool), opts: opts}
+}
+
+func (c *comparer) differ(path string, expected, actual reflect.Value) {
+	c.diffs = append(c.diffs, difference{path: path, expected: expected, actual: actual})
+}
+
+func (c *comparer) compare(e, a reflect.Value, path string) {
+	if !e.IsValid() || !a.IsValid() {
+		if e.IsValid() != a.IsValid() {
+			c.differ(path, e, a)
+		}
+		return
+	}
+	if e.Type() != a.Type() {
+		if c.opts.derefPointers {
+			c.compareDeref(e, a, path)
+			return
+		}
+		c.differ(path, e, a)
+		return
+	}
+	if equal, detail, ok := equivalent(e, a, c.opts); ok {
+		if !equal {
+			c.diffs = append(c.diffs, difference{path: path, expected: e, actual: a, detail: detail})
+		}
+		return
+	}
+	switch e.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if e.Kind() == reflect.Ptr && c.opts.derefPointers {
+			c.compareDeref(e, a, path)
+			return
+		}
+		if e.Pointer() == a.Pointer() {
+			return
+		}
+		if e.IsNil() != a.IsNil() {
+			if e.Kind() != reflect.Map || !c.nilEqualsEmpty(e, a) {
+				c.differ(path, e, a)
+			}
+			return
+		}
+		v := visit{e.Pointer(), a.Pointer(), e.Type()}
+		if c.visited[v] {
+			return
+		}
+		c.visited[v] = true
+		if e.Kind() == reflect.Ptr {
+			c.compare(e.Elem(), a.Elem(), path)
+			return
+		}
+		c.compareMaps(e, a, path)
+	case reflect.Interface:
+		if e.IsNil() != a.IsNil() {
+			c.differ(path, e, a)
+			return
+		}
+		c.compare(e.Elem(), a.Elem(), path)
+	case reflect.Slice:
+		if e.IsNil() != a.IsNil() {
+			if !c.nilEqualsEmpty(e, a) {
+				c.differ(path, e, a)
+			}
+			return
+		}
+		if e.Len() == a.Len() && e.Pointer() == a.Pointer() {
+			return
+		}
+		v := visit{e.Pointer(), a.Pointer(), e.Type()}
+		if c.visited[v] {
+			return
+		}
+		c.visited[v] = true
+		if c.opts.unordered(path) {
+			c.compareUnordered(e, a, path)
+			return
+		}
+		c.compareSeqs(e, a, path)
+	case reflect.Array:
+		c.compareSeqs(e, a, path)
+	case reflect.Struct:
+		for i := 0; i < e.NumField(); i++ {
+			field := e.Type().Field(i)
+			if c.opts.ignoresField(e.Type(), field) {
+				continue
+			}
+			c.compare(e.Field(i), a.Field(i), fieldPath(path, field.Name))
+		}
+	case reflect.Func:
+		if !e.IsNil() || !a.IsNil() {
+			c.differ(path, e, a)
+		}
+	default:
+		if !scalarEqual(e, a) {
+			c.differ(path, e, a)
+		}
+	}
+}
+
+// nilEqualsEmpty reports whether the slices or maps e and a, one of which is
+// nil, are to be considered equal.
+func (c *comparer) nilEqualsEmpty(e, a reflect.Value) bool {
+	return c.opts.NilEqualsEmpty && e.Len() == 0 && a.Len() == 0
+}
+
+func (c *comparer) compareSeqs(e, a reflect.Value, path string) {
+	for i := 0; i < e.Len() || i < a.Len(); i++ {
+		idxPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= a.Len():
+			c.differ(idxPath, e.Index(i), reflect.Value{})
+		case i >= e.Len():
+			c.differ(idxPath, reflect.Value{}, a.Index(i))
+		default:
+			c.compare(e.Index(i), a.Index(i), idxPath)
+		}
+	}
+}
+
+func (c *comparer) compareMaps(e, a reflect.Value, path string) {
+	for _, key := range e.MapKeys() {
+		ev, av := e.MapIndex(key), a.MapIndex(key)
+		if !av.IsValid() && c.derefNil(ev) {
+			continue
+		}
+		c.compare(ev, av, keyPath(path, key))
+	}
+	for _, key := range a.MapKeys() {
+		if av := a.MapIndex(key); !e.MapIndex(key).IsValid() && !c.derefNil(av) {
+			c.differ(keyPath(path, key), reflect.Value{}, av)
+		}
+	}
+}
+
+// displayPath returns path in a form suitable for failure messages.
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func keyPath(path string, key reflect.Value) string {
+	if key.CanInterface() {
+		return fmt.Sprintf("%s[%#v]", path, key.Interface())
+	}
+	return fmt.Sprintf("%s[%v]", path, key)
+}
+
+// scalarEqual compares two values of the same non-composite type. It works on
+// values obtained through unexported fields, which cannot be converted to
+// interface{}.
+func scalarEqual(e, a reflect.Value) bool {
+	switch e.Kind() {
+	case reflect.Bool:
+		return e.Bool() == a.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.Int() == a.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.Uint() == a.Uint()
+	case reflect.Float32, reflect.Float64:
+		return e.Float() == a.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return e.Complex() == a.Complex()
+	case reflect.String:
+		return e.String() == a.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return e.Pointer() == a.Pointer()
+	}
+	panic("scalarEqual called on " + e.Kind().String())
+}
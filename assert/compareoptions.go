@@ -0,0 +1,266 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Options configures the comparison made by DeepEqualWithOptions. The zero
+// value compares with the same semantics as DeepEqual.
+type Options struct {
+	// IgnoreFields names exported struct fields which are ignored wherever
+	// they occur, at any depth.
+	IgnoreFields []string
+	// IgnorePaths lists the paths of values which are ignored, in the form
+	// used in failure messages, such as `Items[0].ID` or `Labels["env"]`. An
+	// index or key of [*] matches any index or key.
+	IgnorePaths []string
+	// FloatTolerance is the greatest absolute difference at which two
+	// floating point values are considered equal.
+	FloatTolerance float64
+	// TimeTolerance is the greatest difference at which two time.Time values
+	// are considered equal. Even without a tolerance, time.Time values are
+	// compared as instants, ignoring their locations and monotonic clock
+	// readings.
+	TimeTolerance time.Duration
+	// NilEqualsEmpty causes nil slices and maps to be considered equal to
+	// empty ones.
+	NilEqualsEmpty bool
+	// Comparers holds Comparators for specific types, which take precedence
+	// over any registered with RegisterComparator.
+	Comparers map[reflect.Type]Comparator
+	// IgnoreUnexported lists struct types whose unexported fields, such as
+	// mutexes and caches, are ignored, and omitted from the failure diff.
+	IgnoreUnexported []reflect.Type
+	// IgnoreAllUnexported causes the unexported fields of every struct type
+	// to be ignored.
+	IgnoreAllUnexported bool
+	// UnorderedSlices causes all slices to be compared as multisets, ignoring
+	// the order of their elements.
+	UnorderedSlices bool
+	// UnorderedPaths lists the paths of slices which are compared as
+	// multisets, in the form used for IgnorePaths.
+	UnorderedPaths []string
+
+	// derefPointers causes values of different types to be compared once
+	// pointers are dereferenced, as for DeepEqualDeref.
+	derefPointers bool
+}
+
+// WithIgnoreUnexported causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to ignore the unexported fields of the struct types
+// of the given values, such as WithIgnoreUnexported(Server{}), and to omit
+// them from the failure diff. With no values, the unexported fields of every
+// struct type are ignored.
+func WithIgnoreUnexported(types ...interface{}) Option {
+	opts := Options{IgnoreAllUnexported: len(types) == 0}
+	for _, typ := range types {
+		opts.IgnoreUnexported = append(opts.IgnoreUnexported, reflect.TypeOf(typ))
+	}
+	return func(o *options) {
+		o.compare = o.compare.merge(opts)
+	}
+}
+
+// WithIgnoredFields causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to ignore the values at the given paths, such as
+// "User.CreatedAt" or "Sessions[*].Token", by zeroing them on both sides
+// before comparing. Paths take the form used in failure messages, as
+// described for Options.IgnorePaths. Ignored values are shown as <ignored> in
+// the failure diff.
+func WithIgnoredFields(paths ...string) Option {
+	return func(o *options) {
+		o.compare = o.compare.merge(Options{IgnorePaths: paths})
+	}
+}
+
+// ignoresPath reports whether the value at path is ignored by IgnorePaths.
+func (o *Options) ignoresPath(path string) bool {
+	if len(o.IgnorePaths) == 0 {
+		return false
+	}
+	segments := splitPath(path)
+	for _, p := range o.IgnorePaths {
+		if pathMatches(splitPath(p), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoresFieldName reports whether the exported field name is ignored by
+// IgnoreFields.
+func (o *Options) ignoresFieldName(name string) bool {
+	for _, f := range o.IgnoreFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoresField reports whether field, of struct type typ, is to be ignored.
+func (o *Options) ignoresField(typ reflect.Type, field reflect.StructField) bool {
+	if field.PkgPath == "" {
+		return false
+	}
+	if o.IgnoreAllUnexported {
+		return true
+	}
+	for _, t := range o.IgnoreUnexported {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// merge returns o combined with other. Ignore rules and comparers are
+// combined, and any tolerance set in other takes precedence over that in o,
+// as does any comparer in other for the same type.
+func (o Options) merge(other Options) Options {
+	o.IgnoreFields = append(append([]string(nil), o.IgnoreFields...), other.IgnoreFields...)
+	o.IgnorePaths = append(append([]string(nil), o.IgnorePaths...), other.IgnorePaths...)
+	if other.FloatTolerance > 0 {
+		o.FloatTolerance = other.FloatTolerance
+	}
+	if other.TimeTolerance > 0 {
+		o.TimeTolerance = other.TimeTolerance
+	}
+	o.NilEqualsEmpty = o.NilEqualsEmpty || other.NilEqualsEmpty
+	if len(other.Comparers) > 0 {
+		comparers := make(map[reflect.Type]Comparator, len(o.Comparers)+len(other.Comparers))
+		for typ, fn := range o.Comparers {
+			comparers[typ] = fn
+		}
+		for typ, fn := range other.Comparers {
+			comparers[typ] = fn
+		}
+		o.Comparers = comparers
+	}
+	o.IgnoreUnexported = append(append([]reflect.Type(nil), o.IgnoreUnexported...), other.IgnoreUnexported...)
+	o.IgnoreAllUnexported = o.IgnoreAllUnexported || other.IgnoreAllUnexported
+	o.UnorderedSlices = o.UnorderedSlices || other.UnorderedSlices
+	o.UnorderedPaths = append(append([]string(nil), o.UnorderedPaths...), other.UnorderedPaths...)
+	o.derefPointers = o.derefPointers || other.derefPointers
+	return o
+}
+
+// lenient reports whether o causes values to be considered equal which
+// reflect.DeepEqual considers unequal.
+func (o *Options) lenient() bool {
+	return o.FloatTolerance > 0 || o.TimeTolerance > 0 || o.NilEqualsEmpty || len(o.Comparers) > 0 ||
+		len(o.IgnoreUnexported) > 0 || o.IgnoreAllUnexported || len(o.IgnoreFields) > 0 || len(o.IgnorePaths) > 0 ||
+		o.UnorderedSlices || len(o.UnorderedPaths) > 0 || o.derefPointers
+}
+
+// DeepEqualWithOptions asserts that two objects are deeply equal, subject to
+// opts, which bundles ignore rules, tolerances for floats and times,
+// leniency between nil and empty collections, and custom comparers. Ignored
+// values are omitted from the failure diff, and values considered equal under
+// opts are rendered identically on both sides of it.
+func DeepEqualWithOptions(t TestingT, expected, actual interface{}, opts Options, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	if o.cmp {
+		return cmpDeepEqual(t, expected, actual, o, msgAndArgs...)
+	}
+	opts = opts.merge(o.compare)
+	if len(opts.IgnoreFields) > 0 || len(opts.IgnorePaths) > 0 {
+		expected, actual = maskFields(expected, &opts), maskFields(actual, &opts)
+	}
+	diffs := differences(expected, actual, &opts)
+	if len(diffs) == 0 {
+		return true
+	}
+	msg := "Structs differ"
+	for _, d := range diffs {
+		if d.detail != "" {
+			msg += fmt.Sprintf("\n%s: %s", displayPath(d.path), d.detail)
+		}
+	}
+	o.compare = opts
+	return failInterfaceDiff(t, msg, expected, actual, o, msgAndArgs...)
+}
+
+// DeepEqualWithOptions asserts that two objects are deeply equal, subject to
+// opts, which bundles ignore rules, tolerances for floats and times,
+// leniency between nil and empty collections, and custom comparers. Ignored
+// values are omitted from the failure diff, and values considered equal under
+// opts are rendered identically on both sides of it.
+func (a *Assertions) DeepEqualWithOptions(expected, actual interface{}, opts Options, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualWithOptions(a.t, expected, actual, opts, a.withOptions(msgAndArgs)...)
+}
+
+// WithTimeTolerance causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to consider two time.Time values equal if they are
+// within d of each other, wherever they occur.
+func WithTimeTolerance(d time.Duration) Option {
+	return func(o *options) {
+		o.compare = o.compare.merge(Options{TimeTolerance: d})
+	}
+}
+
+// WithFloatTolerance causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to consider two floating point values equal if they
+// are within epsilon of each other, wherever they occur.
+func WithFloatTolerance(epsilon float64) Option {
+	return func(o *options) {
+		o.compare = o.compare.merge(Options{FloatTolerance: epsilon})
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// containsTime reports whether values of type typ may contain a time.Time,
+// other than through an interface.
+func containsTime(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if typ == nil || seen[typ] {
+		return false
+	}
+	if typ == timeType {
+		return true
+	}
+	seen[typ] = true
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsTime(typ.Elem(), seen)
+	case reflect.Map:
+		return containsTime(typ.Key(), seen) || containsTime(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if containsTime(typ.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// equivalent compares e and a, which are of the same type, with any
+// comparer or tolerance which applies to them. ok is false if none applies.
+// time.Time values are always compared as instants, as by time.Time.Equal,
+// so that differences of location and monotonic clock reading are ignored.
+func equivalent(e, a reflect.Value, opts *Options) (equal bool, detail string, ok bool) {
+	if equal, detail, ok := compareWithComparator(e, a, opts); ok {
+		return equal, detail, true
+	}
+	switch {
+	case e.Type() == timeType && e.CanInterface() && a.CanInterface():
+		delta := e.Interface().(time.Time).Sub(a.Interface().(time.Time))
+		if delta < 0 {
+			delta = -delta
+		}
+		return delta <= opts.TimeTolerance, "", true
+	case opts.FloatTolerance > 0 && (e.Kind() == reflect.Float32 || e.Kind() == reflect.Float64):
+		return math.Abs(e.Float()-a.Float()) <= opts.FloatTolerance, "", true
+	}
+	return false, "", false
+}
@@ -0,0 +1,234 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// origin returns a Comparator which reports, as its diff, where it came from,
+// so that the comparers chosen by Options.merge can be told apart.
+func origin(name string) Comparator {
+	return func(_, _ interface{}) (bool, string) {
+		return false, name
+	}
+}
+
+func TestOptionsMerge(t *testing.T) {
+	moneyType, timeType := reflect.TypeOf(money{}), reflect.TypeOf(time.Time{})
+	tests := []struct {
+		name      string
+		o, other  Options
+		want      Options
+		comparers map[reflect.Type]string
+	}{
+		{
+			name:  "ignore rules are combined",
+			o:     Options{IgnoreFields: []string{"ID"}, IgnorePaths: []string{"Items[*].ID"}},
+			other: Options{IgnoreFields: []string{"CreatedAt"}, IgnorePaths: []string{"Meta"}},
+			want:  Options{IgnoreFields: []string{"ID", "CreatedAt"}, IgnorePaths: []string{"Items[*].ID", "Meta"}},
+		},
+		{
+			name:  "later tolerances win",
+			o:     Options{FloatTolerance: 0.1, TimeTolerance: time.Second},
+			other: Options{FloatTolerance: 0.01, TimeTolerance: time.Minute},
+			want:  Options{FloatTolerance: 0.01, TimeTolerance: time.Minute},
+		},
+		{
+			name:  "unset tolerances do not override",
+			o:     Options{FloatTolerance: 0.1, TimeTolerance: time.Second},
+			other: Options{NilEqualsEmpty: true},
+			want:  Options{FloatTolerance: 0.1, TimeTolerance: time.Second, NilEqualsEmpty: true},
+		},
+		{
+			name:  "booleans are OR-ed",
+			o:     Options{NilEqualsEmpty: true, UnorderedSlices: true},
+			other: Options{IgnoreAllUnexported: true, UnorderedPaths: []string{"Tags"}},
+			want:  Options{NilEqualsEmpty: true, UnorderedSlices: true, IgnoreAllUnexported: true, UnorderedPaths: []string{"Tags"}},
+		},
+		{
+			name:      "comparers are overridden per type",
+			o:         Options{Comparers: map[reflect.Type]Comparator{moneyType: origin("o"), timeType: origin("o")}},
+			other:     Options{Comparers: map[reflect.Type]Comparator{moneyType: origin("other")}},
+			comparers: map[reflect.Type]string{moneyType: "other", timeType: "o"},
+		},
+		{
+			name:      "comparers are kept",
+			o:         Options{Comparers: map[reflect.Type]Comparator{moneyType: origin("o")}},
+			other:     Options{FloatTolerance: 1},
+			want:      Options{FloatTolerance: 1},
+			comparers: map[reflect.Type]string{moneyType: "o"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.o.merge(tt.other)
+			comparers := map[reflect.Type]string{}
+			for typ, fn := range got.Comparers {
+				_, comparers[typ] = fn(nil, nil)
+			}
+			if len(comparers) > 0 || len(tt.comparers) > 0 {
+				if !reflect.DeepEqual(comparers, tt.comparers) {
+					t.Errorf("comparers = %v, want %v", comparers, tt.comparers)
+				}
+			}
+			got.Comparers = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("merge returned %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionsMergeDoesNotModify(t *testing.T) {
+	o := Options{IgnorePaths: make([]string, 1, 2)}
+	a := o.merge(Options{IgnorePaths: []string{"A"}})
+	b := o.merge(Options{IgnorePaths: []string{"B"}})
+	if a.IgnorePaths[1] != "A" || b.IgnorePaths[1] != "B" {
+		t.Errorf("merged options share IgnorePaths: %q, %q", a.IgnorePaths, b.IgnorePaths)
+	}
+}
+
+type account struct {
+	ID      int
+	Name    string
+	Balance float64
+	Opened  time.Time
+	Tags    []string
+	Meta    map[string]string
+	Fee     money
+	secret  string
+}
+
+func TestDeepEqualWithOptions(t *testing.T) {
+	opened := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	base := account{ID: 1, Name: "a", Balance: 10, Opened: opened, Tags: []string{"x", "y"}, Fee: money{100, 2}}
+	changed := account{
+		ID: 2, Name: "a", Balance: 10.05, Opened: opened.Add(2 * time.Second),
+		Tags: []string{"y", "x"}, Meta: map[string]string{}, Fee: money{1000, 3}, secret: "s",
+	}
+	all := Options{
+		IgnoreFields:     []string{"ID"},
+		FloatTolerance:   0.1,
+		TimeTolerance:    5 * time.Second,
+		NilEqualsEmpty:   true,
+		Comparers:        map[reflect.Type]Comparator{reflect.TypeOf(money{}): compareMoney},
+		UnorderedPaths:   []string{"Tags"},
+		IgnoreUnexported: []reflect.Type{reflect.TypeOf(account{})},
+	}
+	without := func(f func(o *Options)) Options {
+		o := all
+		f(&o)
+		return o
+	}
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		opts             Options
+		msgAndArgs       []interface{}
+		want             string
+	}{
+		{name: "all options", expected: base, actual: changed, opts: all},
+		{
+			name:     "no ignored fields",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.IgnoreFields = nil }),
+			want: "Structs differ\n-  ID: (int) 1,\n+  ID: (int) 2,",
+		},
+		{
+			name:     "ignored path",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.IgnoreFields, o.IgnorePaths = nil, []string{"ID"} }),
+		},
+		{
+			name:     "no float tolerance",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.FloatTolerance = 0 }),
+			want: "Structs differ\n-  Balance: (float64) 10,\n+  Balance: (float64) 10.05,",
+		},
+		{
+			name:     "no time tolerance",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.TimeTolerance = 0 }),
+			want: "Structs differ\nOpened",
+		},
+		{
+			name:     "nil not equal to empty",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.NilEqualsEmpty = false }),
+			want: "Structs differ\n-  Meta: (map[string]string) <nil>,\n+  Meta: (map[string]string) (len=0) {",
+		},
+		{
+			name:     "no comparer",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.Comparers = nil }),
+			want: "Structs differ\nFee",
+		},
+		{
+			name:     "ordered tags",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.UnorderedPaths = nil }),
+			want: "Structs differ\nTags",
+		},
+		{
+			name:     "unexported fields compared",
+			expected: base, actual: changed,
+			opts: without(func(o *Options) { o.IgnoreUnexported = nil }),
+			want: "Structs differ\n+  secret: (string) (len=1) \"s\"",
+		},
+		{
+			name:     "tight tolerance in msgAndArgs wins",
+			expected: base, actual: changed,
+			opts:       all,
+			msgAndArgs: []interface{}{WithFloatTolerance(0.01)},
+			want:       "Structs differ\n-  Balance: (float64) 10,\n+  Balance: (float64) 10.05,",
+		},
+		{
+			name:     "loose tolerance in msgAndArgs wins",
+			expected: base, actual: changed,
+			opts:       without(func(o *Options) { o.FloatTolerance, o.TimeTolerance = 0.01, time.Second }),
+			msgAndArgs: []interface{}{WithFloatTolerance(0.1), WithTimeTolerance(time.Minute)},
+		},
+		{
+			name:     "comparer in msgAndArgs overrides",
+			expected: base, actual: changed,
+			opts:       all,
+			msgAndArgs: []interface{}{WithComparer(func(a, b money) bool { return a == b })},
+			want:       "Structs differ\nFee",
+		},
+		{
+			name:     "ignore rules in msgAndArgs are merged",
+			expected: base, actual: changed,
+			opts:       without(func(o *Options) { o.IgnoreFields, o.FloatTolerance = nil, 0 }),
+			msgAndArgs: []interface{}{WithIgnoredFields("ID", "Balance")},
+		},
+		{
+			name:     "leniency in msgAndArgs is merged",
+			expected: base, actual: changed,
+			opts:       without(func(o *Options) { o.NilEqualsEmpty, o.UnorderedPaths = false, nil }),
+			msgAndArgs: []interface{}{WithNilEqualsEmpty(), WithUnorderedSlices()},
+		},
+		{
+			name:     "message after options",
+			expected: base, actual: account{ID: 1, Name: "b"},
+			opts:       all,
+			msgAndArgs: []interface{}{WithIgnoredFields("Tags"), "checking %s", "accounts"},
+			want:       "Structs differ\n-  Name: (string) (len=1) \"a\",\n+  Name: (string) (len=1) \"b\",\nchecking accounts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualWithOptions(m, tt.expected, tt.actual, tt.opts, tt.msgAndArgs...)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualWithOptions returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CookiesEqual asserts that the two slices contain equivalent cookies. Cookies
+// are matched by name, domain and path, without regard to order, and compared
+// on their Value, HttpOnly, Secure and SameSite fields. Volatile fields, such
+// as Expires and MaxAge, are ignored.
+func CookiesEqual(t TestingT, expected, actual []*http.Cookie, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp := cookiesByKey(expected)
+	act := cookiesByKey(actual)
+	var differ []string
+	for key, e := range exp {
+		a, ok := act[key]
+		switch {
+		case !ok:
+			differ = append(differ, fmt.Sprintf("%s (missing)", key))
+		case formatCookie(e) != formatCookie(a):
+			differ = append(differ, key.String())
+		}
+	}
+	for key := range act {
+		if _, ok := exp[key]; !ok {
+			differ = append(differ, fmt.Sprintf("%s (unexpected)", key))
+		}
+	}
+	if len(differ) == 0 {
+		return true
+	}
+	sort.Strings(differ)
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "Cookies differ: "+strings.Join(differ, ", "), formatCookies(exp), formatCookies(act), o, msgAndArgs...)
+}
+
+// CookiesEqual asserts that the two slices contain equivalent cookies. Cookies
+// are matched by name, domain and path, without regard to order, and compared
+// on their Value, HttpOnly, Secure and SameSite fields. Volatile fields, such
+// as Expires and MaxAge, are ignored.
+func (a *Assertions) CookiesEqual(expected, actual []*http.Cookie, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CookiesEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// CookieEqual asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, sets the cookie name, with a Set-Cookie header,
+// equivalent to expected. The cookies are compared on their Value, Path,
+// Domain, Expires, MaxAge, Secure, HttpOnly and SameSite fields. Expires and
+// MaxAge may differ by up to the tolerance given with WithTimeTolerance. On
+// failure, a field-by-field diff is shown.
+func CookieEqual(t TestingT, resp interface{}, name string, expected *http.Cookie, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var cookies []*http.Cookie
+	switch r := resp.(type) {
+	case *http.Response:
+		if r == nil {
+			return Fail(t, "Response is nil", msgAndArgs...)
+		}
+		cookies = r.Cookies()
+	case *httptest.ResponseRecorder:
+		if r == nil {
+			return Fail(t, "Response recorder is nil", msgAndArgs...)
+		}
+		cookies = r.Result().Cookies()
+	default:
+		return Fail(t, fmt.Sprintf("Unsupported response type: %T", resp), msgAndArgs...)
+	}
+	var actual *http.Cookie
+	names := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		names = append(names, c.Name)
+		if c.Name == name {
+			actual = c
+		}
+	}
+	if actual == nil {
+		sort.Strings(names)
+		return Fail(t, fmt.Sprintf("Response does not set cookie %q; it sets %s", name, formatNames(names)), msgAndArgs...)
+	}
+	if expected == nil {
+		return Fail(t, "Expected cookie is nil", msgAndArgs...)
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	aligned := *actual
+	tolerance := o.compare.TimeTolerance
+	if d := aligned.Expires.Sub(expected.Expires); d >= -tolerance && d <= tolerance {
+		aligned.Expires = expected.Expires
+	}
+	if d := time.Duration(aligned.MaxAge-expected.MaxAge) * time.Second; d >= -tolerance && d <= tolerance {
+		aligned.MaxAge = expected.MaxAge
+	}
+	exp, act := formatCookieFields(expected, name), formatCookieFields(&aligned, name)
+	if exp == act {
+		return true
+	}
+	var differ []string
+	expLines, actLines := strings.Split(exp, "\n"), strings.Split(act, "\n")
+	for i := range expLines {
+		if expLines[i] != actLines[i] {
+			differ = append(differ, strings.TrimSpace(strings.SplitN(expLines[i], ":", 2)[0]))
+		}
+	}
+	return failDiff(t, fmt.Sprintf("Cookie %q differs: %s", name, strings.Join(differ, ", ")), exp, act, o, msgAndArgs...)
+}
+
+// CookieEqual asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, sets the cookie name, with a Set-Cookie header,
+// equivalent to expected. The cookies are compared on their Value, Path,
+// Domain, Expires, MaxAge, Secure, HttpOnly and SameSite fields. Expires and
+// MaxAge may differ by up to the tolerance given with WithTimeTolerance. On
+// failure, a field-by-field diff is shown.
+func (a *Assertions) CookieEqual(resp interface{}, name string, expected *http.Cookie, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CookieEqual(a.t, resp, name, expected, a.withOptions(msgAndArgs)...)
+}
+
+// formatCookieFields renders every field of c compared by CookieEqual, one
+// per line. The name is given, as the expected cookie need not set it.
+func formatCookieFields(c *http.Cookie, name string) string {
+	expires := "unset"
+	if !c.Expires.IsZero() {
+		expires = c.Expires.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("Cookie: %s\n  Value: %q\n  Path: %q\n  Domain: %q\n  Expires: %s\n  MaxAge: %d\n  Secure: %t\n  HttpOnly: %t\n  SameSite: %s\n",
+		name, c.Value, c.Path, c.Domain, expires, c.MaxAge, c.Secure, c.HttpOnly, sameSiteString(c.SameSite))
+}
+
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return "no cookies"
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// cookieKey identifies a cookie. Cookies of the same name, but of different
+// domains or paths, are distinct.
+type cookieKey struct {
+	name, domain, path string
+}
+
+// String returns the name of the cookie, qualified by its domain and path, if
+// they are set.
+func (k cookieKey) String() string {
+	s := k.name
+	if k.domain != "" {
+		s += "; Domain=" + k.domain
+	}
+	if k.path != "" {
+		s += "; Path=" + k.path
+	}
+	return s
+}
+
+func cookiesByKey(cookies []*http.Cookie) map[cookieKey]*http.Cookie {
+	byKey := make(map[cookieKey]*http.Cookie, len(cookies))
+	for _, c := range cookies {
+		if c != nil {
+			byKey[cookieKey{name: c.Name, domain: c.Domain, path: c.Path}] = c
+		}
+	}
+	return byKey
+}
+
+// formatCookie renders the compared fields of a cookie, one per line.
+func formatCookie(c *http.Cookie) string {
+	return fmt.Sprintf("Cookie: %s\n  Value: %q\n  Path: %q\n  Domain: %q\n  HttpOnly: %t\n  Secure: %t\n  SameSite: %s\n",
+		c.Name, c.Value, c.Path, c.Domain, c.HttpOnly, c.Secure, sameSiteString(c.SameSite))
+}
+
+func formatCookies(cookies map[cookieKey]*http.Cookie) string {
+	keys := make([]cookieKey, 0, len(cookies))
+	for key := range cookies {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	buf := &bytes.Buffer{}
+	for _, key := range keys {
+		buf.WriteString(formatCookie(cookies[key]))
+	}
+	return buf.String()
+}
+
+func sameSiteString(s http.SameSite) string {
+	switch s {
+	case http.SameSiteDefaultMode:
+		return "Default"
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+	return "unset"
+}
@@ -0,0 +1,88 @@
+package assert
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCookiesEqual(t *testing.T) {
+	session := &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode}
+	theme := &http.Cookie{Name: "theme", Value: "dark"}
+	tests := []struct {
+		name             string
+		expected, actual []*http.Cookie
+		want             string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "any order",
+			expected: []*http.Cookie{session, theme},
+			actual:   []*http.Cookie{theme, session},
+		},
+		{
+			name:     "volatile fields ignored",
+			expected: []*http.Cookie{session},
+			actual: []*http.Cookie{{
+				Name: "session", Value: "abc", Path: "/", HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode,
+				Expires: time.Now().Add(time.Hour), MaxAge: 3600, Raw: "session=abc",
+			}},
+		},
+		{
+			name:     "differing value",
+			expected: []*http.Cookie{session, theme},
+			actual:   []*http.Cookie{session, {Name: "theme", Value: "light"}},
+			want:     "Cookies differ: theme\n",
+		},
+		{
+			name:     "differing flag",
+			expected: []*http.Cookie{session},
+			actual:   []*http.Cookie{{Name: "session", Value: "abc", Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode}},
+			want:     "Cookies differ: session; Path=/\n",
+		},
+		{
+			name:     "missing",
+			expected: []*http.Cookie{session, theme},
+			actual:   []*http.Cookie{session},
+			want:     "Cookies differ: theme (missing)",
+		},
+		{
+			name:     "unexpected",
+			expected: []*http.Cookie{session},
+			actual:   []*http.Cookie{session, theme},
+			want:     "Cookies differ: theme (unexpected)",
+		},
+		{
+			name:     "same name, different paths",
+			expected: []*http.Cookie{{Name: "id", Value: "1", Path: "/"}, {Name: "id", Value: "2", Path: "/admin"}},
+			actual:   []*http.Cookie{{Name: "id", Value: "2", Path: "/admin"}, {Name: "id", Value: "1", Path: "/"}},
+		},
+		{
+			name:     "same name, one path missing",
+			expected: []*http.Cookie{{Name: "id", Value: "1", Path: "/"}, {Name: "id", Value: "2", Path: "/admin"}},
+			actual:   []*http.Cookie{{Name: "id", Value: "2", Path: "/admin"}},
+			want:     "Cookies differ: id; Path=/ (missing)",
+		},
+		{
+			name:     "same name, different domains",
+			expected: []*http.Cookie{{Name: "id", Value: "1", Domain: "a.example.com"}},
+			actual:   []*http.Cookie{{Name: "id", Value: "1", Domain: "b.example.com"}},
+			want:     "Cookies differ: id; Domain=a.example.com (missing), id; Domain=b.example.com (unexpected)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := CookiesEqual(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("CookiesEqual returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
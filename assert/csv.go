@@ -0,0 +1,182 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// maxCSVDifferences is the greatest number of cell differences listed in a
+// CSVEqual failure message.
+const maxCSVDifferences = 10
+
+// WithCSVHeader causes CSVEqual to treat the first row of each document as a
+// header, naming columns in failure messages by their headers.
+func WithCSVHeader() Option {
+	return func(o *options) {
+		o.csvHeader = true
+	}
+}
+
+// WithUnorderedCSVColumns causes CSVEqual to match columns by their headers,
+// rather than by position, so that the order of columns is ignored. It
+// implies WithCSVHeader.
+func WithUnorderedCSVColumns() Option {
+	return func(o *options) {
+		o.csvHeader = true
+		o.csvUnorderedColumns = true
+	}
+}
+
+// CSVEqual asserts that the two CSV documents are equal, comparing them row
+// by row and cell by cell. On failure, the row and column coordinates of the
+// first differences are reported, followed by a diff of the documents. The
+// WithCSVHeader and WithUnorderedCSVColumns options may be passed to make the
+// comparison aware of a header row.
+func CSVEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	exp, err := parseCSV(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected CSV: %s", err), msgAndArgs...)
+	}
+	act, err := parseCSV(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing actual CSV: %s", err), msgAndArgs...)
+	}
+	if o.csvUnorderedColumns && len(exp) > 0 && len(act) > 0 {
+		var msg string
+		act, msg = reorderCSVColumns(exp[0], act)
+		if msg != "" {
+			return Fail(t, msg, msgAndArgs...)
+		}
+	}
+	diffs := csvDifferences(exp, act, o.csvHeader)
+	if len(diffs) == 0 {
+		return true
+	}
+	msg := "CSV documents differ"
+	for i, d := range diffs {
+		if i == maxCSVDifferences {
+			msg += fmt.Sprintf("\n... and %d more", len(diffs)-i)
+			break
+		}
+		msg += "\n" + d
+	}
+	return failDiff(t, msg, formatCSV(exp), formatCSV(act), o, msgAndArgs...)
+}
+
+// CSVEqual asserts that the two CSV documents are equal, comparing them row
+// by row and cell by cell. On failure, the row and column coordinates of the
+// first differences are reported, followed by a diff of the documents. The
+// WithCSVHeader and WithUnorderedCSVColumns options may be passed to make the
+// comparison aware of a header row.
+func (a *Assertions) CSVEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CSVEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+func parseCSV(doc string) ([][]string, error) {
+	r := csv.NewReader(strings.NewReader(doc))
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+func formatCSV(rows [][]string) string {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.WriteAll(rows)
+	return buf.String()
+}
+
+// reorderCSVColumns returns rows with its columns rearranged to match the
+// order of header. If the headers do not name the same columns, a failure
+// message is returned instead.
+func reorderCSVColumns(header []string, rows [][]string) ([][]string, string) {
+	index := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		index[name] = i
+	}
+	var missing, extra []string
+	order := make([]int, len(header))
+	for i, name := range header {
+		j, ok := index[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		order[i] = j
+		delete(index, name)
+	}
+	for _, name := range rows[0] {
+		if _, ok := index[name]; ok {
+			extra = append(extra, name)
+		}
+	}
+	if len(missing) > 0 || len(extra) > 0 {
+		msg := "CSV columns differ"
+		if len(missing) > 0 {
+			msg += fmt.Sprintf("\nmissing columns: %s", strings.Join(missing, ", "))
+		}
+		if len(extra) > 0 {
+			msg += fmt.Sprintf("\nunexpected columns: %s", strings.Join(extra, ", "))
+		}
+		return nil, msg
+	}
+	result := make([][]string, len(rows))
+	for r, row := range rows {
+		result[r] = make([]string, len(order))
+		for i, j := range order {
+			if j < len(row) {
+				result[r][i] = row[j]
+			}
+		}
+		// Cells beyond the header are kept, in their original order.
+		if len(row) > len(rows[0]) {
+			result[r] = append(result[r], row[len(rows[0]):]...)
+		}
+	}
+	return result, ""
+}
+
+// csvDifferences describes each cell at which exp and act differ.
+func csvDifferences(exp, act [][]string, header bool) []string {
+	var names []string
+	if header && len(exp) > 0 {
+		names = exp[0]
+	}
+	column := func(i int) string {
+		if i < len(names) && names[i] != "" {
+			return fmt.Sprintf("column %d (%s)", i+1, names[i])
+		}
+		return fmt.Sprintf("column %d", i+1)
+	}
+	var diffs []string
+	for r := 0; r < len(exp) || r < len(act); r++ {
+		switch {
+		case r >= len(act):
+			diffs = append(diffs, fmt.Sprintf("row %d: missing", r+1))
+			continue
+		case r >= len(exp):
+			diffs = append(diffs, fmt.Sprintf("row %d: unexpected", r+1))
+			continue
+		}
+		e, a := exp[r], act[r]
+		for c := 0; c < len(e) || c < len(a); c++ {
+			switch {
+			case c >= len(a):
+				diffs = append(diffs, fmt.Sprintf("row %d, %s: expected %q, actual missing", r+1, column(c), e[c]))
+			case c >= len(e):
+				diffs = append(diffs, fmt.Sprintf("row %d, %s: unexpected %q", r+1, column(c), a[c]))
+			case e[c] != a[c]:
+				diffs = append(diffs, fmt.Sprintf("row %d, %s: expected %q, actual %q", r+1, column(c), e[c], a[c]))
+			}
+		}
+	}
+	return diffs
+}
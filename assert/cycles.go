@@ -0,0 +1,116 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ref identifies the target of a pointer, map, or slice, so that reference
+// cycles may be detected.
+type ref struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// refOf returns the ref of v, which must be a non-nil pointer, map or slice.
+func refOf(v reflect.Value) ref {
+	r := ref{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		r.len = v.Len()
+	}
+	return r
+}
+
+// hasCycle reports whether v contains a reference cycle, through pointers,
+// maps, or slices.
+func hasCycle(v reflect.Value) bool {
+	// A ref is on the current path while its contents are walked, and done
+	// once they have been, so that shared values are only walked once.
+	const (
+		onPath = 1
+		done   = 2
+	)
+	state := make(map[ref]int)
+	var walk func(v reflect.Value) bool
+	walk = func(v reflect.Value) bool {
+		switch v.Kind() {
+		case reflect.Interface:
+			return !v.IsNil() && walk(v.Elem())
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			if v.IsNil() || (v.Kind() == reflect.Slice && v.Len() == 0) {
+				return false
+			}
+			r := refOf(v)
+			switch state[r] {
+			case onPath:
+				return true
+			case done:
+				return false
+			}
+			state[r] = onPath
+			switch v.Kind() {
+			case reflect.Ptr:
+				if walk(v.Elem()) {
+					return true
+				}
+			case reflect.Map:
+				for _, key := range v.MapKeys() {
+					if walk(key) || walk(v.MapIndex(key)) {
+						return true
+					}
+				}
+			default:
+				for i := 0; i < v.Len(); i++ {
+					if walk(v.Index(i)) {
+						return true
+					}
+				}
+			}
+			state[r] = done
+		case reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				if walk(v.Index(i)) {
+					return true
+				}
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if walk(v.Field(i)) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return walk(v)
+}
+
+// enter records that the value referenced by v, a non-nil pointer, map or
+// slice, is being dumped. If it is already being dumped, further up, a
+// back-reference to it is written instead, and enter returns false. When
+// d.cycles is set, the targets of back-references are numbered, so that the
+// back-references can identify them.
+func (d *dumper) enter(v reflect.Value) (ref, bool) {
+	r := refOf(v)
+	if d.onPath[r] {
+		d.backRefs[r] = true
+		fmt.Fprintf(d.buf, "↩ cycle to #%d", d.cycles[r])
+		return r, false
+	}
+	d.onPath[r] = true
+	if n, ok := d.cycles[r]; ok {
+		if n == 0 {
+			d.nextCycle++
+			n = d.nextCycle
+			d.cycles[r] = n
+		}
+		fmt.Fprintf(d.buf, "#%d ", n)
+	}
+	return r, true
+}
+
+// leave records that the value identified by r has been dumped.
+func (d *dumper) leave(r ref) {
+	delete(d.onPath, r)
+}
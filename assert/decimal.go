@@ -0,0 +1,49 @@
+package assert
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// DecimalEqual asserts that the two decimals represent the same value,
+// regardless of their internal exponents, so that 1.0 and 1.00 are equal.
+func DecimalEqual(t TestingT, expected, actual decimal.Decimal, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if expected.Equal(actual) {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Decimals differ:\nexpected: %s\nactual  : %s", expected.String(), actual.String()), msgAndArgs...)
+}
+
+// DecimalEqual asserts that the two decimals represent the same value,
+// regardless of their internal exponents, so that 1.0 and 1.00 are equal.
+func (a *Assertions) DecimalEqual(expected, actual decimal.Decimal, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DecimalEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// DecimalInDelta asserts that the two decimals differ by no more than delta.
+func DecimalInDelta(t TestingT, expected, actual, delta decimal.Decimal, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	dt := expected.Sub(actual).Abs()
+	if dt.LessThanOrEqual(delta.Abs()) {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Decimals differ by more than %s:\nexpected: %s\nactual  : %s\ndelta   : %s",
+		delta.String(), expected.String(), actual.String(), dt.String()), msgAndArgs...)
+}
+
+// DecimalInDelta asserts that the two decimals differ by no more than delta.
+func (a *Assertions) DecimalInDelta(expected, actual, delta decimal.Decimal, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DecimalInDelta(a.t, expected, actual, delta, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDecimalEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		want             string
+	}{
+		{name: "identical", expected: "1.5", actual: "1.5"},
+		{name: "differently scaled", expected: "1.0", actual: "1.00"},
+		{name: "trailing zeros", expected: "100", actual: "100.000"},
+		{name: "negative zero", expected: "0", actual: "-0.00"},
+		{
+			name:     "differing",
+			expected: "1.00",
+			actual:   "1.01",
+			want:     "Decimals differ:\nexpected: 1\nactual  : 1.01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DecimalEqual(m, decimal.RequireFromString(tt.expected), decimal.RequireFromString(tt.actual))
+			if got != (tt.want == "") {
+				t.Fatalf("DecimalEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalInDelta(t *testing.T) {
+	tests := []struct {
+		name                    string
+		expected, actual, delta string
+		want                    string
+	}{
+		{name: "equal", expected: "1.0", actual: "1.00", delta: "0"},
+		{name: "within delta", expected: "1.00", actual: "1.004", delta: "0.005"},
+		{name: "at delta", expected: "1.00", actual: "0.995", delta: "0.005"},
+		{name: "negative delta", expected: "1.00", actual: "1.005", delta: "-0.005"},
+		{
+			name:     "beyond delta",
+			expected: "1.00",
+			actual:   "1.006",
+			delta:    "0.005",
+			want:     "Decimals differ by more than 0.005:\nexpected: 1\nactual  : 1.006\ndelta   : 0.006",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DecimalInDelta(m, decimal.RequireFromString(tt.expected), decimal.RequireFromString(tt.actual), decimal.RequireFromString(tt.delta))
+			if got != (tt.want == "") {
+				t.Fatalf("DecimalInDelta returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
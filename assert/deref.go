@@ -0,0 +1,148 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DeepEqualDeref asserts that two objects are deeply equal, after
+// dereferencing any pointers, at any depth, whether held in maps, slices or
+// struct fields. This allows a map[string]*T to be compared to an equivalent
+// map[string]T. A nil pointer is treated as the zero value of the type it
+// points to, and a map entry holding a nil pointer is also treated as an
+// absent key. On failure, the path to the first difference is reported.
+func DeepEqualDeref(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	opts := o.compare.merge(Options{derefPointers: true})
+	diffs := differences(expected, actual, &opts)
+	if len(diffs) == 0 {
+		return true
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].path < diffs[j].path
+	})
+	path := displayPath(diffs[0].path)
+	switch {
+	case !diffs[0].expected.IsValid():
+		path += " (unexpected)"
+	case !diffs[0].actual.IsValid():
+		path += " (missing)"
+	}
+	o.compare = opts
+	return failInterfaceDiff(t, fmt.Sprintf("Values differ at %s", path), expected, actual, o, msgAndArgs...)
+}
+
+// DeepEqualDeref asserts that two objects are deeply equal, after
+// dereferencing any pointers, at any depth, whether held in maps, slices or
+// struct fields. This allows a map[string]*T to be compared to an equivalent
+// map[string]T. A nil pointer is treated as the zero value of the type it
+// points to, and a map entry holding a nil pointer is also treated as an
+// absent key. On failure, the path to the first difference is reported.
+func (a *Assertions) DeepEqualDeref(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualDeref(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// compareDeref compares e and a, at least one of which is a pointer or of a
+// type differing from the other, once any pointers have been dereferenced.
+// Maps and sequences whose element types differ only in pointers are compared
+// element by element.
+func (c *comparer) compareDeref(e, a reflect.Value, path string) {
+	if e.Kind() == reflect.Ptr && a.Kind() == reflect.Ptr && !e.IsNil() && !a.IsNil() {
+		v := visit{e.Pointer(), a.Pointer(), e.Type()}
+		if c.visited[v] {
+			return
+		}
+		c.visited[v] = true
+	}
+	ev, av := derefOrZero(e), derefOrZero(a)
+	if ev.Type() == av.Type() {
+		c.compare(ev, av, path)
+		return
+	}
+	switch {
+	case ev.Kind() == reflect.Map && av.Kind() == reflect.Map && ev.Type().Key() == av.Type().Key():
+		if ev.IsNil() != av.IsNil() {
+			if !c.nilEqualsEmpty(ev, av) {
+				c.differ(path, e, a)
+			}
+			return
+		}
+		v := visit{ev.Pointer(), av.Pointer(), ev.Type()}
+		if c.visited[v] {
+			return
+		}
+		c.visited[v] = true
+		c.compareMaps(ev, av, path)
+	case ev.Kind() == reflect.Slice && av.Kind() == reflect.Slice:
+		if ev.IsNil() != av.IsNil() {
+			if !c.nilEqualsEmpty(ev, av) {
+				c.differ(path, e, a)
+			}
+			return
+		}
+		v := visit{ev.Pointer(), av.Pointer(), ev.Type()}
+		if c.visited[v] {
+			return
+		}
+		c.visited[v] = true
+		c.compareSeqs(ev, av, path)
+	case ev.Kind() == reflect.Array && av.Kind() == reflect.Array:
+		c.compareSeqs(ev, av, path)
+	default:
+		c.differ(path, e, a)
+	}
+}
+
+// derefNil reports whether v, a map value, holds a nil pointer which is to be
+// treated as an absent key.
+func (c *comparer) derefNil(v reflect.Value) bool {
+	if !c.opts.derefPointers {
+		return false
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	_, ok := indirectValue(v)
+	return !ok
+}
+
+// derefOrZero dereferences v, and any interface it holds, until it is
+// neither a pointer nor a non-nil interface. A nil pointer yields the zero
+// value of the type it ultimately points to.
+func derefOrZero(v reflect.Value) reflect.Value {
+	for {
+		switch {
+		case v.Kind() == reflect.Interface && !v.IsNil():
+			v = v.Elem()
+		case v.Kind() == reflect.Ptr && v.IsNil():
+			t := v.Type().Elem()
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			return reflect.Zero(t)
+		case v.Kind() == reflect.Ptr:
+			v = v.Elem()
+		default:
+			return v
+		}
+	}
+}
+
+// indirectValue dereferences v until it is no longer a pointer. It returns
+// false if a nil pointer is encountered.
+func indirectValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
@@ -0,0 +1,140 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type derefItem struct {
+	Name string
+	Tags map[string]*int
+}
+
+type derefNode struct {
+	Value int
+	Next  *derefNode
+}
+
+func TestDeepEqualDeref(t *testing.T) {
+	one, two := 1, 2
+	cycle := func(v int) *derefNode {
+		n := &derefNode{Value: v}
+		n.Next = n
+		return n
+	}
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		want             string
+	}{
+		{
+			name:     "pointer map equals value map",
+			expected: map[string]*derefItem{"a": {Name: "a"}, "b": {Name: "b"}},
+			actual:   map[string]derefItem{"a": {Name: "a"}, "b": {Name: "b"}},
+		},
+		{
+			name:     "pointer slice equals value slice",
+			expected: []*int{&one, &two},
+			actual:   []int{1, 2},
+		},
+		{
+			name:     "pointers in interfaces",
+			expected: []interface{}{&one, nil},
+			actual:   []interface{}{1, nil},
+		},
+		{
+			name:     "nil pointers on both sides",
+			expected: map[string]*int{"a": nil},
+			actual:   map[string]**int{"a": nil},
+		},
+		{
+			name:     "differing value",
+			expected: map[string]*derefItem{"a": {Name: "a"}, "b": {Name: "b"}},
+			actual:   map[string]derefItem{"a": {Name: "a"}, "b": {Name: "x"}},
+			want:     `Values differ at ["b"].Name`,
+		},
+		{
+			name:     "missing key",
+			expected: map[string]*int{"a": &one, "b": &two},
+			actual:   map[string]int{"a": 1},
+			want:     `Values differ at ["b"] (missing)`,
+		},
+		{
+			name:     "unexpected key",
+			expected: map[string]*int{"a": &one},
+			actual:   map[string]int{"a": 1, "b": 2},
+			want:     `Values differ at ["b"] (unexpected)`,
+		},
+		{
+			name:     "nil map value equals absent key",
+			expected: map[string]*int{"a": nil, "b": &one},
+			actual:   map[string]int{"b": 1},
+		},
+		{
+			name:     "absent key equals nil map value",
+			expected: map[string]int{"b": 1},
+			actual:   map[string]interface{}{"a": (*int)(nil), "b": &one},
+		},
+		{
+			name:     "nil map value equals zero",
+			expected: map[string]*int{"a": nil},
+			actual:   map[string]int{"a": 0},
+		},
+		{
+			name:     "nil slice element equals zero",
+			expected: []*int{nil},
+			actual:   []int{0},
+		},
+		{
+			name:     "nil pointer of the same type equals zero",
+			expected: []*derefItem{nil},
+			actual:   []*derefItem{{}},
+		},
+		{
+			name:     "nil pointer to pointer equals zero",
+			expected: map[string]**int{"a": nil},
+			actual:   map[string]int{"a": 0},
+		},
+		{
+			name:     "nil pointer against non-zero",
+			expected: []*int{nil},
+			actual:   []int{1},
+			want:     `Values differ at [0]`,
+		},
+		{
+			name:     "zero value is not absent",
+			expected: map[string]int{"a": 0},
+			actual:   map[string]*int{},
+			want:     `Values differ at ["a"] (missing)`,
+		},
+		{
+			name:     "nil pointer in struct field",
+			expected: map[string]*derefItem{"a": {Tags: map[string]*int{"x": &one}}},
+			actual:   map[string]derefItem{"a": {Tags: map[string]*int{"x": nil}}},
+			want:     `Values differ at ["a"].Tags["x"]`,
+		},
+		{
+			name:     "cycle",
+			expected: cycle(1),
+			actual:   cycle(1),
+		},
+		{
+			name:     "shorter slice",
+			expected: []*int{&one, &two},
+			actual:   []int{1},
+			want:     `Values differ at [1] (missing)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualDeref(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualDeref returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
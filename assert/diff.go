@@ -0,0 +1,157 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// DiffAlgorithm selects the algorithm used to compute diffs.
+type DiffAlgorithm int
+
+const (
+	// Difflib computes diffs with difflib's sequence matcher, a port of
+	// Python's difflib. This is the default.
+	Difflib DiffAlgorithm = iota
+	// Myers computes diffs with the Myers O(ND) algorithm, which always
+	// produces a minimal edit script. This may be more accurate than Difflib
+	// for large inputs, such as after a large refactor.
+	Myers
+)
+
+// WithDiffAlgorithm selects the algorithm used to produce diffs.
+func WithDiffAlgorithm(algo DiffAlgorithm) Option {
+	return func(o *options) {
+		o.diffAlgorithm = algo
+	}
+}
+
+// defaultDiffContext is the number of unchanged lines shown around each
+// change in a diff, unless overridden with WithDiffContext.
+const defaultDiffContext = 2
+
+// WithDiffContext sets the number of unchanged lines shown around each change
+// in a diff.
+func WithDiffContext(lines int) Option {
+	return func(o *options) {
+		if lines >= 0 {
+			o.diffContext = lines
+		}
+	}
+}
+
+// Diff returns the diff of the expected and actual strings, as shown by
+// LinesEqual, rendered according to any of opts which affect diffs, such as
+// WithDiffContext or WithSideBySideDiff. It returns "" if the strings are
+// equal. The result may be reported with FailDiff.
+func Diff(expected, actual string, opts ...Option) string {
+	if expected == actual {
+		return ""
+	}
+	o, _ := parseOptions(nil)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return diff(expected, actual, o)
+}
+
+// opCodes returns the opcodes transforming a into b, according to the
+// selected algorithm.
+func opCodes(a, b []string, algo DiffAlgorithm) []difflib.OpCode {
+	if algo == Myers {
+		return myersOpCodes(a, b)
+	}
+	return difflib.NewMatcher(a, b).GetOpCodes()
+}
+
+// groupOpCodes isolates change clusters by eliminating ranges with no changes,
+// leaving up to context lines of context around each change. It is
+// equivalent to difflib's SequenceMatcher.GetGroupedOpCodes.
+func groupOpCodes(codes []difflib.OpCode, context int) [][]difflib.OpCode {
+	if len(codes) == 0 {
+		codes = []difflib.OpCode{{Tag: 'e', I1: 0, I2: 1, J1: 0, J2: 1}}
+	}
+	codes = append([]difflib.OpCode(nil), codes...)
+	if c := codes[0]; c.Tag == 'e' {
+		codes[0] = difflib.OpCode{Tag: c.Tag, I1: maxInt(c.I1, c.I2-context), I2: c.I2, J1: maxInt(c.J1, c.J2-context), J2: c.J2}
+	}
+	if c := codes[len(codes)-1]; c.Tag == 'e' {
+		codes[len(codes)-1] = difflib.OpCode{Tag: c.Tag, I1: c.I1, I2: minInt(c.I2, c.I1+context), J1: c.J1, J2: minInt(c.J2, c.J1+context)}
+	}
+	var groups [][]difflib.OpCode
+	var group []difflib.OpCode
+	for _, c := range codes {
+		i1, i2, j1, j2 := c.I1, c.I2, c.J1, c.J2
+		if c.Tag == 'e' && i2-i1 > 2*context {
+			group = append(group, difflib.OpCode{Tag: c.Tag, I1: i1, I2: minInt(i2, i1+context), J1: j1, J2: minInt(j2, j1+context)})
+			groups = append(groups, group)
+			group = nil
+			i1, j1 = maxInt(i1, i2-context), maxInt(j1, j2-context)
+		}
+		group = append(group, difflib.OpCode{Tag: c.Tag, I1: i1, I2: i2, J1: j1, J2: j2})
+	}
+	if len(group) > 0 && !(len(group) == 1 && group[0].Tag == 'e') {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// unifiedDiff renders the grouped opcodes as a unified diff of a and b.
+func unifiedDiff(a, b []string, groups [][]difflib.OpCode) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString("--- expected\n+++ actual\n")
+	for _, g := range groups {
+		first, last := g[0], g[len(g)-1]
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n", unifiedRange(first.I1, last.I2), unifiedRange(first.J1, last.J2))
+		for _, c := range g {
+			if c.Tag == 'e' {
+				for _, line := range a[c.I1:c.I2] {
+					buf.WriteString(" " + line)
+				}
+				continue
+			}
+			if c.Tag == 'r' || c.Tag == 'd' {
+				for _, line := range a[c.I1:c.I2] {
+					buf.WriteString("-" + line)
+				}
+			}
+			if c.Tag == 'r' || c.Tag == 'i' {
+				for _, line := range b[c.J1:c.J2] {
+					buf.WriteString("+" + line)
+				}
+			}
+		}
+	}
+	return buf.String()
+}
+
+// unifiedRange formats a hunk range in unified diff format.
+func unifiedRange(start, stop int) string {
+	beginning := start + 1
+	length := stop - start
+	if length == 1 {
+		return fmt.Sprintf("%d", beginning)
+	}
+	if length == 0 {
+		beginning--
+	}
+	return fmt.Sprintf("%d,%d", beginning, length)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// WithDiffDir causes diffs larger than threshold bytes to be written to files
+// beneath dir, rather than being included in the test output. The full
+// expected and actual dumps are written alongside the diff, and only a short
+// message naming the diff file is reported. This keeps CI logs readable, while
+// preserving the detail as build artifacts.
+func WithDiffDir(dir string, threshold int) Option {
+	return func(o *options) {
+		o.diffDir = dir
+		o.diffThreshold = threshold
+	}
+}
+
+type namer interface {
+	Name() string
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// writeDiffFiles writes the expected and actual dumps, and the diff between
+// them, to a new directory beneath dir, and returns the path to the diff.
+func writeDiffFiles(t TestingT, dir, expected, actual, diff string) (string, error) {
+	prefix := "failure"
+	if n, ok := t.(namer); ok {
+		prefix = unsafeFileChars.ReplaceAllString(n.Name(), "_")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	dir, err := ioutil.TempDir(dir, prefix+"-")
+	if err != nil {
+		return "", err
+	}
+	files := []struct {
+		name, content string
+	}{
+		{"expected", expected},
+		{"actual", actual},
+		{"diff", diff},
+	}
+	for _, f := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, f.name), []byte(f.content), 0644); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(dir, "diff"), nil
+}
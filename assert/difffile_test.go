@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// namedMockT is a mockT which reports a test name, as *testing.T does.
+type namedMockT struct {
+	mockT
+	name string
+}
+
+func (m *namedMockT) Name() string { return m.name }
+
+func TestWithDiffDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testify-diffdir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	expected, actual := strings.Repeat("a\n", 100), strings.Repeat("b\n", 100)
+
+	t.Run("large diff written to files", func(t *testing.T) {
+		m := &namedMockT{name: "TestX/sub case"}
+		if LinesEqual(m, expected, actual, WithDiffDir(dir, 50)) {
+			t.Fatal("LinesEqual returned true")
+		}
+		out := m.output()
+		if strings.Contains(out, "Diff:") {
+			t.Errorf("failure contains the diff:\n%s", out)
+		}
+		i := strings.Index(out, "Full diff written to ")
+		if i < 0 {
+			t.Fatalf("failure does not name the diff file:\n%s", out)
+		}
+		path := strings.Fields(out[i+len("Full diff written to "):])[0]
+		if got := filepath.Base(filepath.Dir(path)); !strings.HasPrefix(got, "TestX_sub_case-") {
+			t.Errorf("diff directory %q is not named after the test", got)
+		}
+		want := map[string]string{"expected": expected, "actual": actual, "diff": "-a\n"}
+		for name, content := range want {
+			data, err := ioutil.ReadFile(filepath.Join(filepath.Dir(path), name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), content) {
+				t.Errorf("%s file does not contain %q:\n%s", name, content, data)
+			}
+		}
+	})
+
+	t.Run("small diff reported inline", func(t *testing.T) {
+		sub := filepath.Join(dir, "small")
+		m := &mockT{}
+		if LinesEqual(m, "a\n", "b\n", WithDiffDir(sub, 50)) {
+			t.Fatal("LinesEqual returned true")
+		}
+		if out := m.output(); !strings.Contains(out, "Diff:") || strings.Contains(out, "Full diff written to") {
+			t.Errorf("failure does not contain the diff inline:\n%s", out)
+		}
+		if _, err := os.Stat(sub); !os.IsNotExist(err) {
+			t.Errorf("diff directory was created: %v", err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WithMaxDiffLines limits diffs in failure messages to at most lines lines.
+// Anything beyond that is replaced with a summary of how many differing lines
+// and whole hunks were omitted. A limit of 0, the default, means no limit.
+func WithMaxDiffLines(lines int) Option {
+	return func(o *options) {
+		if lines >= 0 {
+			o.maxDiffLines = lines
+		}
+	}
+}
+
+// truncateDiff truncates the unified or side-by-side diff d to max lines,
+// appending a summary of what was omitted.
+func truncateDiff(d string, max int) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(d, "\n"), "\n")
+	if max <= 0 || len(lines) <= max {
+		return d
+	}
+	var differing, hunks int
+	for i, line := range lines[max:] {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			hunks++
+		case max+i < 2 && (strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ")):
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, "+"), strings.HasPrefix(line, "!"):
+			differing++
+		}
+	}
+	summary := fmt.Sprintf("… %s more differing %s", formatThousands(differing), plural(differing, "line", "lines"))
+	if hunks > 0 {
+		summary += fmt.Sprintf(" (%s %s omitted)", formatThousands(hunks), plural(hunks, "hunk", "hunks"))
+	}
+	head := strings.Join(lines[:max], "")
+	if !strings.HasSuffix(head, "\n") {
+		head += "\n"
+	}
+	return head + summary + "\n"
+}
+
+// formatThousands formats n with commas separating groups of thousands.
+func formatThousands(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
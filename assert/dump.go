@@ -0,0 +1,334 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dumper produces a spew-like dump of a value, without the pointer addresses
+// and slice capacities which make spew dumps noisy in diffs. Unlike spew, it
+// can be given the value being compared against, so that the two dumps can be
+// rendered relative to one another.
+type dumper struct {
+	buf          *bytes.Buffer
+	collapseMaps bool
+	compare      *Options
+	// actual is true when dumping the actual value, rather than the expected.
+	actual bool
+	// path is the path, in the form used in failure messages, of the value
+	// being dumped.
+	path string
+
+	// onPath holds the references being dumped, from the root down to the
+	// current value, and backRefs those which were found to be referenced
+	// again from within themselves.
+	onPath, backRefs map[ref]bool
+	// cycles numbers the targets of back-references, as they are dumped.
+	cycles    map[ref]int
+	nextCycle int
+}
+
+// structDumps returns dumps of expected and actual, each rendered relative to
+// the other. Values which a registered Comparator, or o's comparison options,
+// consider equal are rendered identically in both dumps, so they do not appear
+// in the diff.
+func structDumps(expected, actual interface{}, o *options) (string, string) {
+	dump := func(v, other interface{}, isActual bool) string {
+		d := &dumper{
+			collapseMaps: o.collapseMaps,
+			compare:      &o.compare,
+			actual:       isActual,
+		}
+		return d.run(reflect.ValueOf(v), reflect.ValueOf(other), 0) + "\n"
+	}
+	return dump(expected, actual, false), dump(actual, expected, true)
+}
+
+// render returns a dump of v alone, as a nested value at the given depth.
+func (d *dumper) render(v reflect.Value, depth int) string {
+	r := &dumper{compare: d.compare}
+	return r.run(v, reflect.Value{}, depth)
+}
+
+// run returns the dump of v, relative to other, at the given depth. If v
+// contains reference cycles, it is dumped a second time, so that the targets
+// of the back-references found the first time can be numbered.
+func (d *dumper) run(v, other reflect.Value, depth int) string {
+	d.reset(nil)
+	d.dump(v, other, depth)
+	if len(d.backRefs) > 0 {
+		cycles := make(map[ref]int, len(d.backRefs))
+		for r := range d.backRefs {
+			cycles[r] = 0
+		}
+		d.reset(cycles)
+		d.dump(v, other, depth)
+	}
+	return d.buf.String()
+}
+
+func (d *dumper) reset(cycles map[ref]int) {
+	d.buf = &bytes.Buffer{}
+	d.onPath = make(map[ref]bool)
+	d.backRefs = make(map[ref]bool)
+	d.cycles = cycles
+	d.nextCycle = 0
+}
+
+func (d *dumper) indent(depth int) {
+	d.buf.WriteString(strings.Repeat("  ", depth))
+}
+
+// elem dereferences an interface value.
+func elem(v reflect.Value) reflect.Value {
+	if v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		return v.Elem()
+	}
+	return v
+}
+
+func (d *dumper) dump(v, other reflect.Value, depth int) {
+	if d.path != "" && d.compare.ignoresPath(d.path) {
+		d.buf.WriteString("<ignored>")
+		return
+	}
+	v, other = elem(v), elem(other)
+	if v.IsValid() && other.IsValid() && v.Type() == other.Type() {
+		e, a := v, other
+		if d.actual {
+			e, a = other, v
+		}
+		if equal, _, ok := equivalent(e, a, d.compare); ok && equal {
+			v, other = e, reflect.Value{}
+		}
+	}
+	if !v.IsValid() {
+		d.buf.WriteString("(interface {}) <nil>")
+		return
+	}
+	fmt.Fprintf(d.buf, "(%s) ", v.Type())
+	d.dumpValue(v, other, depth)
+}
+
+func (d *dumper) dumpValue(v, other reflect.Value, depth int) {
+	if other.IsValid() && other.Type() != v.Type() {
+		other = reflect.Value{}
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			d.buf.WriteString("<nil>")
+			return
+		}
+		d.dump(v.Elem(), reflect.Value{}, depth)
+	case reflect.Ptr:
+		if v.IsNil() {
+			d.buf.WriteString("<nil>")
+			return
+		}
+		r, ok := d.enter(v)
+		if !ok {
+			return
+		}
+		defer d.leave(r)
+		if other.IsValid() && !other.IsNil() {
+			other = other.Elem()
+		} else {
+			other = reflect.Value{}
+		}
+		d.dumpValue(v.Elem(), other, depth)
+	case reflect.String:
+		fmt.Fprintf(d.buf, "(len=%d) %q", v.Len(), v.String())
+	case reflect.Slice:
+		if v.IsNil() && !d.compare.NilEqualsEmpty {
+			d.buf.WriteString("<nil>")
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(d.buf, "(len=%d) %q", v.Len(), v.Bytes())
+			return
+		}
+		if v.Len() > 0 {
+			r, ok := d.enter(v)
+			if !ok {
+				return
+			}
+			defer d.leave(r)
+		}
+		d.dumpSeq(v, other, depth)
+	case reflect.Array:
+		d.dumpSeq(v, other, depth)
+	case reflect.Map:
+		if v.IsNil() && !d.compare.NilEqualsEmpty {
+			d.buf.WriteString("<nil>")
+			return
+		}
+		if !v.IsNil() {
+			r, ok := d.enter(v)
+			if !ok {
+				return
+			}
+			defer d.leave(r)
+		}
+		d.dumpMap(v, other, depth)
+	case reflect.Struct:
+		if v.Type() == timeType && v.CanInterface() {
+			d.buf.WriteString(v.Interface().(time.Time).Format(time.RFC3339Nano))
+			return
+		}
+		d.dumpStruct(v, other, depth)
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			d.buf.WriteString("<nil>")
+			return
+		}
+		fmt.Fprintf(d.buf, "<%s>", v.Kind())
+	case reflect.Bool:
+		fmt.Fprintf(d.buf, "%t", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(d.buf, "%d", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(d.buf, "%d", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(d.buf, "%v", v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprintf(d.buf, "%v", v.Complex())
+	default:
+		fmt.Fprintf(d.buf, "<%s>", v.Kind())
+	}
+}
+
+func (d *dumper) dumpSeq(v, other reflect.Value, depth int) {
+	fmt.Fprintf(d.buf, "(len=%d) {\n", v.Len())
+	order, others := d.seqOrder(v, other)
+	for n, i := range order {
+		var o reflect.Value
+		if others[n] >= 0 {
+			o = other.Index(others[n])
+		}
+		d.indent(depth + 1)
+		d.dumpAt(fmt.Sprintf("%s[%d]", d.path, i), v.Index(i), o, depth+1)
+		if n < len(order)-1 {
+			d.buf.WriteRune(',')
+		}
+		d.buf.WriteRune('\n')
+	}
+	d.indent(depth)
+	d.buf.WriteRune('}')
+}
+
+// seqOrder returns the indexes of the elements of v, and of the corresponding
+// elements of other, in the order in which they are to be dumped. An index
+// of -1 means no corresponding element.
+func (d *dumper) seqOrder(v, other reflect.Value) (order, others []int) {
+	if other.IsValid() && v.Kind() == reflect.Slice && d.compare.unordered(d.path) {
+		return d.unorderedSeq(v, other)
+	}
+	order, others = make([]int, v.Len()), make([]int, v.Len())
+	for i := range order {
+		order[i], others[i] = i, -1
+		if other.IsValid() && i < other.Len() {
+			others[i] = i
+		}
+	}
+	return order, others
+}
+
+func (d *dumper) dumpStruct(v, other reflect.Value, depth int) {
+	var fields []int
+	for i := 0; i < v.NumField(); i++ {
+		if !d.compare.ignoresField(v.Type(), v.Type().Field(i)) {
+			fields = append(fields, i)
+		}
+	}
+	if len(fields) == 0 {
+		d.buf.WriteString("{}")
+		return
+	}
+	d.buf.WriteString("{\n")
+	for n, i := range fields {
+		var o reflect.Value
+		if other.IsValid() {
+			o = other.Field(i)
+		}
+		field := v.Type().Field(i)
+		d.indent(depth + 1)
+		fmt.Fprintf(d.buf, "%s: ", field.Name)
+		if field.PkgPath == "" && d.compare.ignoresFieldName(field.Name) {
+			d.buf.WriteString("<ignored>")
+		} else {
+			d.dumpAt(fieldPath(d.path, field.Name), v.Field(i), o, depth+1)
+		}
+		if n < len(fields)-1 {
+			d.buf.WriteRune(',')
+		}
+		d.buf.WriteRune('\n')
+	}
+	d.indent(depth)
+	d.buf.WriteRune('}')
+}
+
+// dumpAt dumps v, the value at path.
+func (d *dumper) dumpAt(path string, v, other reflect.Value, depth int) {
+	parent := d.path
+	d.path = path
+	d.dump(v, other, depth)
+	d.path = parent
+}
+
+type mapEntry struct {
+	key      string
+	path     string
+	value    reflect.Value
+	otherVal reflect.Value
+}
+
+func (d *dumper) dumpMap(v, other reflect.Value, depth int) {
+	fmt.Fprintf(d.buf, "(len=%d) {\n", v.Len())
+	entries := make([]mapEntry, 0, v.Len())
+	var unchanged int
+	for _, key := range v.MapKeys() {
+		var o reflect.Value
+		if other.IsValid() && !other.IsNil() {
+			o = other.MapIndex(key)
+		}
+		val := v.MapIndex(key)
+		if d.collapseMaps && o.IsValid() && valuesEqual(val, o, d.compare) {
+			unchanged++
+			continue
+		}
+		entries = append(entries, mapEntry{
+			key:      d.render(key, depth+1),
+			path:     keyPath(d.path, key),
+			value:    val,
+			otherVal: o,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	if unchanged > 0 {
+		d.indent(depth + 1)
+		fmt.Fprintf(d.buf, "(%d unchanged entries)", unchanged)
+		if len(entries) > 0 {
+			d.buf.WriteRune(',')
+		}
+		d.buf.WriteRune('\n')
+	}
+	for i, entry := range entries {
+		d.indent(depth + 1)
+		d.buf.WriteString(entry.key)
+		d.buf.WriteString(": ")
+		d.dumpAt(entry.path, entry.value, entry.otherVal, depth+1)
+		if i < len(entries)-1 {
+			d.buf.WriteRune(',')
+		}
+		d.buf.WriteRune('\n')
+	}
+	d.indent(depth)
+	d.buf.WriteRune('}')
+}
@@ -0,0 +1,142 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type dumpWide struct {
+	Name   string
+	Labels map[string]int
+}
+
+func TestCollapsedMapsWideMap(t *testing.T) {
+	expected := dumpWide{Name: "x", Labels: map[string]int{}}
+	actual := dumpWide{Name: "x", Labels: map[string]int{}}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("k%02d", i)
+		expected.Labels[key], actual.Labels[key] = i, i
+	}
+	actual.Labels["k50"] = -1
+
+	m := &mockT{}
+	if DeepEqual(m, expected, actual, WithCollapsedMaps()) {
+		t.Fatal("DeepEqual passed unequal values")
+	}
+	out := m.output()
+	for _, want := range []string{
+		"(99 unchanged entries),",
+		`-    (string) (len=3) "k50": (int) 50`,
+		`+    (string) (len=3) "k50": (int) -1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("failure does not contain %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{`"k49"`, `"k51"`} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("failure contains unchanged entry %s:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestStructDumps(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		opts             []Option
+		wantExp, wantAct string
+	}{
+		{
+			name:     "all entries",
+			expected: map[string]int{"a": 1, "b": 2},
+			actual:   map[string]int{"a": 1, "b": 3},
+			wantExp: `(map[string]int) (len=2) {
+  (string) (len=1) "a": (int) 1,
+  (string) (len=1) "b": (int) 2
+}
+`,
+			wantAct: `(map[string]int) (len=2) {
+  (string) (len=1) "a": (int) 1,
+  (string) (len=1) "b": (int) 3
+}
+`,
+		},
+		{
+			name:     "collapsed changed entry",
+			expected: map[string]int{"a": 1, "b": 2},
+			actual:   map[string]int{"a": 1, "b": 3},
+			opts:     []Option{WithCollapsedMaps()},
+			wantExp: `(map[string]int) (len=2) {
+  (1 unchanged entries),
+  (string) (len=1) "b": (int) 2
+}
+`,
+			wantAct: `(map[string]int) (len=2) {
+  (1 unchanged entries),
+  (string) (len=1) "b": (int) 3
+}
+`,
+		},
+		{
+			name:     "collapsed added and removed entries",
+			expected: map[string]int{"a": 1, "b": 2},
+			actual:   map[string]int{"a": 1, "c": 3},
+			opts:     []Option{WithCollapsedMaps()},
+			wantExp: `(map[string]int) (len=2) {
+  (1 unchanged entries),
+  (string) (len=1) "b": (int) 2
+}
+`,
+			wantAct: `(map[string]int) (len=2) {
+  (1 unchanged entries),
+  (string) (len=1) "c": (int) 3
+}
+`,
+		},
+		{
+			name:     "collapsed map in struct",
+			expected: dumpWide{Name: "x", Labels: map[string]int{"a": 1}},
+			actual:   dumpWide{Name: "y", Labels: map[string]int{"a": 1}},
+			opts:     []Option{WithCollapsedMaps()},
+			wantExp: `(assert.dumpWide) {
+  Name: (string) (len=1) "x",
+  Labels: (map[string]int) (len=1) {
+    (1 unchanged entries)
+  }
+}
+`,
+			wantAct: `(assert.dumpWide) {
+  Name: (string) (len=1) "y",
+  Labels: (map[string]int) (len=1) {
+    (1 unchanged entries)
+  }
+}
+`,
+		},
+		{
+			name:     "nil map",
+			expected: map[string]int(nil),
+			actual:   map[string]int{},
+			opts:     []Option{WithCollapsedMaps()},
+			wantExp:  "(map[string]int) <nil>\n",
+			wantAct:  "(map[string]int) (len=0) {\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &options{}
+			for _, opt := range tt.opts {
+				opt(o)
+			}
+			gotExp, gotAct := structDumps(tt.expected, tt.actual, o)
+			if gotExp != tt.wantExp {
+				t.Errorf("expected dump:\n%s\nwant:\n%s", gotExp, tt.wantExp)
+			}
+			if gotAct != tt.wantAct {
+				t.Errorf("actual dump:\n%s\nwant:\n%s", gotAct, tt.wantAct)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package assert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// minElementSimilarity is the minimum similarity of the dumps of an expected
+// and an actual element for ElementsMatchDiff to pair them as one element
+// which differs, rather than report one as missing and the other as extra.
+const minElementSimilarity = 0.5
+
+// ElementsMatchDiff asserts that the expected and actual slices or arrays
+// contain the same elements, in any order, with each element matched only
+// once. On failure, it reports the elements only in expected, the elements
+// only in actual, and, for each remaining expected element which it can pair
+// with a similar actual element, a diff of the two. The comparison options
+// accepted by DeepEqual also apply to the elements.
+func ElementsMatchDiff(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, ok := sliceValue(expected)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", expected), msgAndArgs...)
+	}
+	act, ok := sliceValue(actual)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", actual), msgAndArgs...)
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	var onlyExp, onlyAct []int
+	used := make([]bool, act.Len())
+	for i, j := range matchElements(exp, act, "", &o.compare) {
+		if j < 0 {
+			onlyExp = append(onlyExp, i)
+			continue
+		}
+		used[j] = true
+	}
+	for j, u := range used {
+		if !u {
+			onlyAct = append(onlyAct, j)
+		}
+	}
+	if len(onlyExp) == 0 && len(onlyAct) == 0 {
+		return true
+	}
+
+	// Pair the most similar unmatched elements first.
+	type pair struct {
+		i, j             int
+		expDump, actDump string
+		similarity       float64
+	}
+	var candidates []pair
+	for _, i := range onlyExp {
+		for _, j := range onlyAct {
+			e, a := interfaceDumps(exp.Index(i).Interface(), act.Index(j).Interface(), o)
+			if s := lineSimilarity(e, a); s >= minElementSimilarity {
+				candidates = append(candidates, pair{i, j, e, a, s})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(x, y int) bool {
+		return candidates[x].similarity > candidates[y].similarity
+	})
+	pairedExp, pairedAct := make(map[int]bool), make(map[int]bool)
+	var pairs []pair
+	for _, c := range candidates {
+		if !pairedExp[c.i] && !pairedAct[c.j] {
+			pairedExp[c.i], pairedAct[c.j] = true, true
+			pairs = append(pairs, c)
+		}
+	}
+	sort.Slice(pairs, func(x, y int) bool {
+		return pairs[x].i < pairs[y].i
+	})
+
+	var missing, extra, differing []string
+	for _, i := range onlyExp {
+		if !pairedExp[i] {
+			missing = append(missing, elementDump(i, exp.Index(i).Interface(), o))
+		}
+	}
+	for _, j := range onlyAct {
+		if !pairedAct[j] {
+			extra = append(extra, elementDump(j, act.Index(j).Interface(), o))
+		}
+	}
+	for _, p := range pairs {
+		differing = append(differing, indentLines(fmt.Sprintf("expected[%d] vs actual[%d]:\n%s", p.i, p.j, indentLines(diff(p.expDump, p.actDump, o), "  ")), "  "))
+	}
+	msg := "Elements do not match"
+	for _, section := range []struct {
+		heading string
+		entries []string
+	}{
+		{"only in expected", missing},
+		{"only in actual", extra},
+		{"differing", differing},
+	} {
+		if len(section.entries) > 0 {
+			msg += fmt.Sprintf("\n%s (%d):\n%s", section.heading, len(section.entries), strings.Join(section.entries, "\n"))
+		}
+	}
+	return fail(t, msg, o, msgAndArgs...)
+}
+
+// ElementsMatchDiff asserts that the expected and actual slices or arrays
+// contain the same elements, in any order, with each element matched only
+// once. On failure, it reports the elements only in expected, the elements
+// only in actual, and, for each remaining expected element which it can pair
+// with a similar actual element, a diff of the two. The comparison options
+// accepted by DeepEqual also apply to the elements.
+func (a *Assertions) ElementsMatchDiff(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ElementsMatchDiff(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// elementDump returns the dump of element i, v, for an ElementsMatchDiff
+// failure.
+func elementDump(i int, v interface{}, o *options) string {
+	dump, _ := interfaceDumps(v, v, o)
+	return indentLines(fmt.Sprintf("[%d] %s", i, strings.TrimSuffix(dump, "\n")), "  ")
+}
+
+// indentLines prefixes each line of s with prefix, removing any trailing
+// newline.
+func indentLines(s, prefix string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix)
+}
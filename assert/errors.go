@@ -0,0 +1,122 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// StructuredError is an error which exposes a machine-readable code and
+// additional detail fields, as commonly returned by APIs.
+type StructuredError interface {
+	error
+	Code() string
+	Fields() map[string]interface{}
+}
+
+// structuredError holds the compared components of a StructuredError.
+type structuredError struct {
+	Code    string
+	Message string
+	Fields  map[string]interface{}
+}
+
+// StructuredErrorEqual asserts that two errors are equal. When both errors
+// are, or wrap, a StructuredError, as found by errors.As, the codes, messages
+// and fields of those StructuredErrors are compared individually, and the
+// differing components are reported. Otherwise the errors are compared as
+// with DeepEqual.
+func StructuredErrorEqual(t TestingT, expected, actual error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var exp, act StructuredError
+	if !errors.As(expected, &exp) || !errors.As(actual, &act) {
+		return DeepEqual(t, expected, actual, msgAndArgs...)
+	}
+	e := structuredError{Code: exp.Code(), Message: exp.Error(), Fields: exp.Fields()}
+	a := structuredError{Code: act.Code(), Message: act.Error(), Fields: act.Fields()}
+	var differ []string
+	if e.Code != a.Code {
+		differ = append(differ, "code")
+	}
+	if e.Message != a.Message {
+		differ = append(differ, "message")
+	}
+	if !reflect.DeepEqual(e.Fields, a.Fields) {
+		differ = append(differ, "fields")
+	}
+	if len(differ) == 0 {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failInterfaceDiff(t, "Errors differ in "+strings.Join(differ, ", "), e, a, o, msgAndArgs...)
+}
+
+// StructuredErrorEqual asserts that two errors are equal. When both errors
+// are, or wrap, a StructuredError, as found by errors.As, the codes, messages
+// and fields of those StructuredErrors are compared individually, and the
+// differing components are reported. Otherwise the errors are compared as
+// with DeepEqual.
+func (a *Assertions) StructuredErrorEqual(expected, actual error, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return StructuredErrorEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// ErrorEqualIgnoringStack asserts that two errors have the same message
+// chain, ignoring any stack traces attached to them, such as by
+// github.com/pkg/errors. Each error is unwrapped via its Cause or Unwrap
+// method, and the message at each level is compared. On failure, a diff of
+// the two message chains is shown.
+func ErrorEqualIgnoringStack(t TestingT, expected, actual error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, act := messageChain(expected), messageChain(actual)
+	if reflect.DeepEqual(exp, act) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "Errors differ", strings.Join(exp, "\n"), strings.Join(act, "\n"), o, msgAndArgs...)
+}
+
+// ErrorEqualIgnoringStack asserts that two errors have the same message
+// chain, ignoring any stack traces attached to them, such as by
+// github.com/pkg/errors. Each error is unwrapped via its Cause or Unwrap
+// method, and the message at each level is compared. On failure, a diff of
+// the two message chains is shown.
+func (a *Assertions) ErrorEqualIgnoringStack(expected, actual error, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorEqualIgnoringStack(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// messageChain returns the messages of err and each error it wraps. Levels
+// which add no message of their own, such as those which only attach a stack
+// trace, are omitted.
+func messageChain(err error) []string {
+	var chain []string
+	for err != nil {
+		msg := err.Error()
+		next := unwrapError(err)
+		if next == nil || next.Error() != msg {
+			chain = append(chain, msg)
+		}
+		err = next
+	}
+	return chain
+}
+
+func unwrapError(err error) error {
+	switch e := err.(type) {
+	case interface{ Cause() error }:
+		return e.Cause()
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	}
+	return nil
+}
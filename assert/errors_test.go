@@ -0,0 +1,185 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// apiError is a StructuredError, as might be returned by an API client.
+type apiError struct {
+	code   string
+	msg    string
+	fields map[string]interface{}
+}
+
+func (e *apiError) Error() string                  { return e.msg }
+func (e *apiError) Code() string                   { return e.code }
+func (e *apiError) Fields() map[string]interface{} { return e.fields }
+
+func TestStructuredErrorEqual(t *testing.T) {
+	notFound := func(id int) error {
+		return &apiError{code: "not_found", msg: "no such user", fields: map[string]interface{}{"id": id}}
+	}
+	tests := []struct {
+		name             string
+		expected, actual error
+		want             string
+	}{
+		{
+			name:     "matching fields",
+			expected: notFound(1),
+			actual:   notFound(1),
+		},
+		{
+			name:     "mismatched fields",
+			expected: notFound(1),
+			actual:   notFound(2),
+			want:     "Errors differ in fields\n-    (string) (len=2) \"id\": (int) 1\n+    (string) (len=2) \"id\": (int) 2",
+		},
+		{
+			name:     "mismatched code and message",
+			expected: notFound(1),
+			actual:   &apiError{code: "forbidden", msg: "access denied", fields: map[string]interface{}{"id": 1}},
+			want:     "Errors differ in code, message\n-  Code: (string) (len=9) \"not_found\",\n+  Code: (string) (len=9) \"forbidden\",",
+		},
+		{
+			name:     "wrapped actual",
+			expected: notFound(1),
+			actual:   errors.Wrap(notFound(1), "fetching user"),
+		},
+		{
+			name:     "wrapped with %w",
+			expected: notFound(1),
+			actual:   fmt.Errorf("fetching user: %w", notFound(1)),
+		},
+		{
+			name:     "wrapped actual with mismatched fields",
+			expected: notFound(1),
+			actual:   errors.WithStack(notFound(2)),
+			want:     "Errors differ in fields",
+		},
+		{
+			name:     "non-matching type",
+			expected: notFound(1),
+			actual:   errors.New("no such user"),
+			want:     "Structs differ\n-(*assert.apiError)\n+(*errors.fundamental)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := StructuredErrorEqual(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("StructuredErrorEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestMessageChain(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "single",
+			err:  errors.New("root"),
+			want: []string{"root"},
+		},
+		{
+			name: "wrapped",
+			err:  errors.Wrap(errors.New("root"), "ctx"),
+			want: []string{"ctx: root", "root"},
+		},
+		{
+			name: "stack only levels omitted",
+			err:  errors.WithStack(errors.WithStack(errors.New("root"))),
+			want: []string{"root"},
+		},
+		{
+			name: "Unwrap",
+			err:  fmt.Errorf("outer: %w", errors.New("root")),
+			want: []string{"outer: root", "root"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageChain(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorEqualIgnoringStack(t *testing.T) {
+	wrapped := func(msg string) error { return errors.Wrap(errors.New("root"), msg) }
+	tests := []struct {
+		name             string
+		expected, actual error
+		want             string
+	}{
+		{
+			name: "both nil",
+		},
+		{
+			name:     "differing stacks",
+			expected: wrapped("ctx"),
+			actual:   wrapped("ctx"),
+		},
+		{
+			name:     "with and without a stack",
+			expected: fmt.Errorf("ctx: %w", fmt.Errorf("root")),
+			actual:   errors.WithStack(wrapped("ctx")),
+		},
+		{
+			name:     "differing message",
+			expected: wrapped("ctx"),
+			actual:   wrapped("other"),
+			want:     "Errors differ\n-ctx: root\n+other: root\n root",
+		},
+		{
+			name:     "additional level",
+			expected: wrapped("ctx"),
+			actual:   errors.Wrap(wrapped("ctx"), "outer"),
+			want:     "Errors differ\n+outer: ctx: root\n ctx: root",
+		},
+		{
+			name:     "same message, different chain",
+			expected: errors.New("ctx: root"),
+			actual:   wrapped("ctx"),
+			want:     "Errors differ\n ctx: root\n+root",
+		},
+		{
+			name:     "nil actual",
+			expected: wrapped("ctx"),
+			want:     "Errors differ\n-ctx: root",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := ErrorEqualIgnoringStack(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("ErrorEqualIgnoringStack returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
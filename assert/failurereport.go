@@ -0,0 +1,115 @@
+package assert
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// failureReportEnv is the environment variable which, when set to a file
+// path, causes a FailureRecord for each assertion failure to be appended to
+// that file.
+const failureReportEnv = "TESTIFY_FAILURE_REPORT"
+
+// FailureRecord is the machine-readable description of an assertion failure,
+// written as a line of JSON to the failure report.
+type FailureRecord struct {
+	// Assertion is the name of the failed assertion, such as
+	// "assert.DeepEqual" or "require.JSONEqual".
+	Assertion string `json:"assertion"`
+	// Test is the name of the test, if known.
+	Test string `json:"test,omitempty"`
+	// Error is the failure message.
+	Error string `json:"error"`
+	// Message is the message passed to the assertion, if any.
+	Message string `json:"message,omitempty"`
+	// Expected and Actual are the dumps of the values compared, when the
+	// failure includes a diff of them.
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	// Diff is the diff included in the failure, if any.
+	Diff string `json:"diff,omitempty"`
+	// Trace is the file:line of each frame of the call stack, from the caller
+	// of the assertion up to the test function.
+	Trace []string `json:"trace"`
+}
+
+// SetFailureReport causes a FailureRecord, encoded as a single line of JSON,
+// to be written to w for each assertion failure, in addition to the usual
+// failure message. This allows CI tooling to parse failures without scraping
+// test output. It is typically called from TestMain. Passing nil disables the
+// report. Failures may also be appended to a file by setting the
+// TESTIFY_FAILURE_REPORT environment variable to its path.
+func SetFailureReport(w io.Writer) {
+	failureReportMu.Lock()
+	defer failureReportMu.Unlock()
+	failureReport = w
+}
+
+var (
+	// failureReportMu protects failureReport, and serializes writes to
+	// failure reports, so that records from parallel tests are not
+	// interleaved.
+	failureReportMu sync.Mutex
+	failureReport   io.Writer
+)
+
+// reportFailure writes r to the failure reports, if any. Errors writing the
+// report are ignored, so as not to obscure the failure itself.
+func reportFailure(t TestingT, r FailureRecord) {
+	failureReportMu.Lock()
+	defer failureReportMu.Unlock()
+	path := os.Getenv(failureReportEnv)
+	if failureReport == nil && path == "" {
+		return
+	}
+	r.Assertion = assertionName()
+	if n, ok := t.(namer); ok {
+		r.Test = n.Name()
+	}
+	r.Trace = callerInfo()
+	line, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+	if failureReport != nil {
+		failureReport.Write(line)
+	}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return
+		}
+		f.Write(line)
+		f.Close()
+	}
+}
+
+// assertionName returns the name of the outermost function of this module's
+// packages on the call stack, which is the assertion called by the test, such
+// as "assert.DeepEqual". Methods of Assertions are named as the equivalent
+// function.
+func assertionName() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	var name string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, modulePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			if name != "" {
+				break
+			}
+		} else {
+			name = frame.Function
+		}
+		if !more {
+			break
+		}
+	}
+	name = strings.TrimPrefix(name, modulePrefix)
+	return strings.Replace(name, ".(*Assertions)", "", 1)
+}
@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// FieldMaskEqual asserts that the two field masks cover the same fields. Both
+// masks are normalized before comparison, so path order, duplicate paths, and
+// paths already covered by a parent path (such as "a.b" alongside "a") are not
+// significant. On failure, the paths present in only one mask are reported.
+func FieldMaskEqual(t TestingT, expected, actual *fieldmaskpb.FieldMask, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, act := normalizeFieldMask(expected), normalizeFieldMask(actual)
+	var missing, extra []string
+	for _, path := range exp {
+		if !containsString(act, path) {
+			missing = append(missing, path)
+		}
+	}
+	for _, path := range act {
+		if !containsString(exp, path) {
+			extra = append(extra, path)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return true
+	}
+	msg := "Field masks differ"
+	if len(missing) > 0 {
+		msg += fmt.Sprintf("\nonly in expected: %s", strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		msg += fmt.Sprintf("\nonly in actual  : %s", strings.Join(extra, ", "))
+	}
+	return Fail(t, msg, msgAndArgs...)
+}
+
+// FieldMaskEqual asserts that the two field masks cover the same fields. Both
+// masks are normalized before comparison, so path order, duplicate paths, and
+// paths already covered by a parent path (such as "a.b" alongside "a") are not
+// significant. On failure, the paths present in only one mask are reported.
+func (a *Assertions) FieldMaskEqual(expected, actual *fieldmaskpb.FieldMask, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FieldMaskEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// normalizeFieldMask returns the normalized paths of mask, without modifying
+// it.
+func normalizeFieldMask(mask *fieldmaskpb.FieldMask) []string {
+	m := &fieldmaskpb.FieldMask{Paths: append([]string(nil), mask.GetPaths()...)}
+	m.Normalize()
+	return m.GetPaths()
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFieldMaskEqual(t *testing.T) {
+	mask := func(paths ...string) *fieldmaskpb.FieldMask {
+		return &fieldmaskpb.FieldMask{Paths: paths}
+	}
+	tests := []struct {
+		name             string
+		expected, actual *fieldmaskpb.FieldMask
+		want             string
+	}{
+		{
+			name: "both nil",
+		},
+		{
+			name:     "nil and empty",
+			expected: nil,
+			actual:   mask(),
+		},
+		{
+			name:     "reordered paths",
+			expected: mask("a", "b.c", "d"),
+			actual:   mask("d", "a", "b.c"),
+		},
+		{
+			name:     "duplicate paths",
+			expected: mask("a", "b"),
+			actual:   mask("b", "a", "b"),
+		},
+		{
+			name:     "path covered by parent",
+			expected: mask("a", "b"),
+			actual:   mask("a.x", "b", "a"),
+		},
+		{
+			name:     "only in expected",
+			expected: mask("a", "c"),
+			actual:   mask("a"),
+			want:     "Field masks differ\nonly in expected: c",
+		},
+		{
+			name:     "only in actual",
+			expected: mask("a"),
+			actual:   mask("a", "b", "c"),
+			want:     "Field masks differ\nonly in actual  : b, c",
+		},
+		{
+			name:     "child is not its parent",
+			expected: mask("a"),
+			actual:   mask("a.x"),
+			want:     "Field masks differ\nonly in expected: a\nonly in actual  : a.x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := FieldMaskEqual(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("FieldMaskEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeFieldMaskDoesNotModify(t *testing.T) {
+	m := &fieldmaskpb.FieldMask{Paths: []string{"b", "a", "a"}}
+	normalizeFieldMask(m)
+	if want := []string{"b", "a", "a"}; !reflect.DeepEqual(m.Paths, want) {
+		t.Errorf("mask modified to %q, want %q", m.Paths, want)
+	}
+}
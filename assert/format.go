@@ -0,0 +1,25 @@
+package assert
+
+import "fmt"
+
+// FormatsTo asserts that formatting value with the given verb, as
+// fmt.Sprintf(verb, value), produces the expected string. This exercises any
+// Format, GoString, String or Error method of value, with verbs such as %v,
+// %+v, %#v and %s.
+func FormatsTo(t TestingT, verb, expected string, value interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return LinesEqual(t, expected, fmt.Sprintf(verb, value), msgAndArgs...)
+}
+
+// FormatsTo asserts that formatting value with the given verb, as
+// fmt.Sprintf(verb, value), produces the expected string. This exercises any
+// Format, GoString, String or Error method of value, with verbs such as %v,
+// %+v, %#v and %s.
+func (a *Assertions) FormatsTo(verb, expected string, value interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FormatsTo(a.t, verb, expected, value, a.withOptions(msgAndArgs)...)
+}
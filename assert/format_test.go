@@ -0,0 +1,82 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// point implements fmt.Formatter, rendering itself differently for each of
+// the supported verbs.
+type point struct {
+	X, Y int
+}
+
+func (p point) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('#'):
+		fmt.Fprintf(s, "point{X: %d, Y: %d}", p.X, p.Y)
+	case verb == 'v' && s.Flag('+'):
+		fmt.Fprintf(s, "(x=%d, y=%d)", p.X, p.Y)
+	case verb == 'v', verb == 's':
+		fmt.Fprintf(s, "(%d, %d)", p.X, p.Y)
+	default:
+		fmt.Fprintf(s, "%%!%c(point)", verb)
+	}
+}
+
+// celsius implements fmt.Stringer and fmt.GoStringer.
+type celsius float64
+
+func (c celsius) String() string   { return fmt.Sprintf("%.1f°C", float64(c)) }
+func (c celsius) GoString() string { return fmt.Sprintf("celsius(%g)", float64(c)) }
+
+func TestFormatsTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		verb     string
+		expected string
+		value    interface{}
+		want     string
+	}{
+		{name: "Formatter %v", verb: "%v", expected: "(1, 2)", value: point{1, 2}},
+		{name: "Formatter %+v", verb: "%+v", expected: "(x=1, y=2)", value: point{1, 2}},
+		{name: "Formatter %#v", verb: "%#v", expected: "point{X: 1, Y: 2}", value: point{1, 2}},
+		{name: "Formatter %s", verb: "%s", expected: "(1, 2)", value: point{1, 2}},
+		{name: "Formatter other verb", verb: "%d", expected: "%!d(point)", value: point{1, 2}},
+		{name: "Stringer", verb: "%v", expected: "21.5°C", value: celsius(21.5)},
+		{name: "GoStringer", verb: "%#v", expected: "celsius(21.5)", value: celsius(21.5)},
+		{name: "error", verb: "%s", expected: "ctx: root", value: errors.Wrap(errors.New("root"), "ctx")},
+		{name: "verb within text", verb: "at %v.", expected: "at (1, 2).", value: point{1, 2}},
+		{
+			name:     "differing output",
+			verb:     "%+v",
+			expected: "(1, 2)",
+			value:    point{1, 2},
+			want:     "Strings differ\n-(1, 2)\n+(x=1, y=2)",
+		},
+		{
+			name:     "multi-line output",
+			verb:     "%s",
+			expected: "first\nsecond\nthird",
+			value:    errors.New("first\nsecond\nlast"),
+			want:     "Strings differ\n first\n second\n-third\n+last",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := FormatsTo(m, tt.verb, tt.expected, tt.value)
+			if got != (tt.want == "") {
+				t.Fatalf("FormatsTo returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
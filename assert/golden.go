@@ -0,0 +1,44 @@
+package assert
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/flimzy/testify/internal/update"
+)
+
+// MarshalsToJSONFile asserts that the actual interface{} marshals to JSON
+// equivalent to the contents of the file at path. When the tests are run with
+// the -update flag, or with TESTIFY_UPDATE=1, the file is instead rewritten
+// with the indented JSON representation of actual, and the assertion passes.
+func MarshalsToJSONFile(t TestingT, path string, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if update.Enabled() {
+		actualJSON, ok := marshalJSON(t, "actual", actual, msgAndArgs...)
+		if !ok {
+			return false
+		}
+		if err := update.Write(path, append(actualJSON, '\n')); err != nil {
+			return Fail(t, fmt.Sprintf("Error updating golden file: %s", err), msgAndArgs...)
+		}
+		return true
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error reading golden file: %s", err), msgAndArgs...)
+	}
+	return MarshalsToJSON(t, expected, actual, msgAndArgs...)
+}
+
+// MarshalsToJSONFile asserts that the actual interface{} marshals to JSON
+// equivalent to the contents of the file at path. When the tests are run with
+// the -update flag, or with TESTIFY_UPDATE=1, the file is instead rewritten
+// with the indented JSON representation of actual, and the assertion passes.
+func (a *Assertions) MarshalsToJSONFile(path string, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MarshalsToJSONFile(a.t, path, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,97 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphEqual asserts that the two adjacency lists describe the same graph.
+// Each node's neighbor list is treated as a set, so neither the order of
+// neighbors nor duplicate edges are significant. On failure, the missing and
+// extra nodes and edges are reported.
+func GraphEqual(t TestingT, expected, actual map[string][]string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, act := graphEdges(expected), graphEdges(actual)
+	var problems []string
+	for _, node := range sortedNodes(exp, act) {
+		e, inE := exp[node]
+		a, inA := act[node]
+		switch {
+		case !inA:
+			problems = append(problems, fmt.Sprintf("missing node %s", node))
+		case !inE:
+			problems = append(problems, fmt.Sprintf("extra node %s", node))
+		}
+		for _, n := range sortedSet(e) {
+			if !a[n] {
+				problems = append(problems, fmt.Sprintf("missing edge %s -> %s", node, n))
+			}
+		}
+		for _, n := range sortedSet(a) {
+			if !e[n] {
+				problems = append(problems, fmt.Sprintf("extra edge %s -> %s", node, n))
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "Graphs differ:\n"+strings.Join(problems, "\n"), formatGraph(exp), formatGraph(act), o, msgAndArgs...)
+}
+
+// GraphEqual asserts that the two adjacency lists describe the same graph.
+// Each node's neighbor list is treated as a set, so neither the order of
+// neighbors nor duplicate edges are significant. On failure, the missing and
+// extra nodes and edges are reported.
+func (a *Assertions) GraphEqual(expected, actual map[string][]string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return GraphEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+func graphEdges(graph map[string][]string) map[string]map[string]bool {
+	edges := make(map[string]map[string]bool, len(graph))
+	for node, neighbors := range graph {
+		set := make(map[string]bool, len(neighbors))
+		for _, n := range neighbors {
+			set[n] = true
+		}
+		edges[node] = set
+	}
+	return edges
+}
+
+func sortedNodes(graphs ...map[string]map[string]bool) []string {
+	seen := make(map[string]bool)
+	for _, g := range graphs {
+		for node := range g {
+			seen[node] = true
+		}
+	}
+	return sortedSet(seen)
+}
+
+func sortedSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatGraph renders a graph with one node per line, followed by its sorted
+// neighbors.
+func formatGraph(graph map[string]map[string]bool) string {
+	buf := &bytes.Buffer{}
+	for _, node := range sortedNodes(graph) {
+		fmt.Fprintf(buf, "%s -> [%s]\n", node, strings.Join(sortedSet(graph[node]), ", "))
+	}
+	return buf.String()
+}
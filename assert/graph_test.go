@@ -0,0 +1,72 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual map[string][]string
+		want             string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "reordered neighbors",
+			expected: map[string][]string{"a": {"b", "c"}, "b": {"c"}, "c": nil},
+			actual:   map[string][]string{"a": {"c", "b"}, "b": {"c"}, "c": nil},
+		},
+		{
+			name:     "duplicate edges",
+			expected: map[string][]string{"a": {"b"}, "b": nil},
+			actual:   map[string][]string{"a": {"b", "b"}, "b": nil},
+		},
+		{
+			name:     "missing edge",
+			expected: map[string][]string{"a": {"b", "c"}, "b": nil, "c": nil},
+			actual:   map[string][]string{"a": {"b"}, "b": nil, "c": nil},
+			want:     "Graphs differ:\nmissing edge a -> c",
+		},
+		{
+			name:     "extra edge",
+			expected: map[string][]string{"a": {"b"}, "b": nil},
+			actual:   map[string][]string{"a": {"b"}, "b": {"a"}},
+			want:     "Graphs differ:\nextra edge b -> a",
+		},
+		{
+			name:     "missing node",
+			expected: map[string][]string{"a": {"b"}, "b": nil},
+			actual:   map[string][]string{"a": nil},
+			want:     "Graphs differ:\nmissing edge a -> b\nmissing node b",
+		},
+		{
+			name:     "extra node",
+			expected: map[string][]string{"a": nil},
+			actual:   map[string][]string{"a": nil, "z": {"a"}},
+			want:     "Graphs differ:\nextra node z\nextra edge z -> a",
+		},
+		{
+			name:     "diff of sorted adjacency lists",
+			expected: map[string][]string{"a": {"c", "b"}},
+			actual:   map[string][]string{"a": {"b"}},
+			want:     "-a -> [b, c]\n+a -> [b]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := GraphEqual(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("GraphEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package assert
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// DeepEqualJSONGzipped asserts that actualGzipped, once decompressed, is a
+// JSON document equivalent to expected. This is useful for API responses which
+// are stored gzip-compressed.
+func DeepEqualJSONGzipped(t TestingT, expected, actualGzipped []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	actual, err := gunzip(actualGzipped)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error decompressing actual: %s", err), msgAndArgs...)
+	}
+	if !json.Valid(actual) {
+		return Fail(t, fmt.Sprintf("Decompressed actual is not valid JSON:\n%s", actual), msgAndArgs...)
+	}
+	return MarshalsToJSON(t, expected, json.RawMessage(actual), msgAndArgs...)
+}
+
+// DeepEqualJSONGzipped asserts that actualGzipped, once decompressed, is a
+// JSON document equivalent to expected. This is useful for API responses which
+// are stored gzip-compressed.
+func (a *Assertions) DeepEqualJSONGzipped(expected, actualGzipped []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualJSONGzipped(a.t, expected, actualGzipped, a.withOptions(msgAndArgs)...)
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
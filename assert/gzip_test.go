@@ -0,0 +1,86 @@
+package assert
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+func gzipString(t *testing.T, s string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDeepEqualJSONGzipped(t *testing.T) {
+	compressed := gzipString(t, `{"a":1,"b":[1,2]}`)
+	tests := []struct {
+		name     string
+		expected []byte
+		actual   []byte
+		want     string
+	}{
+		{
+			name:     "equal",
+			expected: []byte(`{"b": [1, 2], "a": 1}`),
+			actual:   compressed,
+		},
+		{
+			name:     "differing value",
+			expected: []byte(`{"a": 2, "b": [1, 2]}`),
+			actual:   compressed,
+			want:     "JSON representations differ\n-    \"a\": 2,\n+    \"a\": 1,",
+		},
+		{
+			name:     "not compressed",
+			expected: []byte(`{"a": 1, "b": [1, 2]}`),
+			actual:   []byte(`{"a": 1, "b": [1, 2]}`),
+			want:     "Error decompressing actual: gzip: invalid header",
+		},
+		{
+			name:     "truncated",
+			expected: []byte(`{"a": 1, "b": [1, 2]}`),
+			actual:   compressed[:len(compressed)-4],
+			want:     "Error decompressing actual: unexpected EOF",
+		},
+		{
+			name:     "empty",
+			expected: []byte(`{}`),
+			want:     "Error decompressing actual: EOF",
+		},
+		{
+			name:     "invalid actual JSON",
+			expected: []byte(`{"a": 1}`),
+			actual:   gzipString(t, `{"a": `),
+			want:     "Decompressed actual is not valid JSON:\n{\"a\": ",
+		},
+		{
+			name:     "invalid expected JSON",
+			expected: []byte(`{"a": `),
+			actual:   compressed,
+			want:     "Error unmarshaling expected JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualJSONGzipped(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualJSONGzipped returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// setValuedHeaders are headers whose values are unordered sets, whether
+// given as separate header lines or as comma-separated lists.
+var setValuedHeaders = map[string]bool{
+	"Access-Control-Allow-Headers":  true,
+	"Access-Control-Allow-Methods":  true,
+	"Access-Control-Expose-Headers": true,
+	"Allow":                         true,
+	"Cache-Control":                 true,
+	"Connection":                    true,
+	"Vary":                          true,
+}
+
+// HeadersEqual asserts that the two headers are equivalent. Keys are compared
+// without regard to case. The values of headers which are unordered sets,
+// such as Vary, Allow, or Cache-Control, are compared without regard to their
+// order, or to whether they are given as separate lines or as a
+// comma-separated list; other values are compared in order. On failure, a
+// diff of only the differing headers is shown.
+func HeadersEqual(t TestingT, expected, actual http.Header, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, act := canonicalHeader(expected), canonicalHeader(actual)
+	var differ []string
+	for key, e := range exp {
+		if a, ok := act[key]; !ok || !stringsEqual(e, a) {
+			differ = append(differ, key)
+		}
+	}
+	for key := range act {
+		if _, ok := exp[key]; !ok {
+			differ = append(differ, key)
+		}
+	}
+	if len(differ) == 0 {
+		return true
+	}
+	return failHeaders(t, "Headers differ", differ, exp, act, msgAndArgs...)
+}
+
+// HeadersEqual asserts that the two headers are equivalent. Keys are compared
+// without regard to case. The values of headers which are unordered sets,
+// such as Vary, Allow, or Cache-Control, are compared without regard to their
+// order, or to whether they are given as separate lines or as a
+// comma-separated list; other values are compared in order. On failure, a
+// diff of only the differing headers is shown.
+func (a *Assertions) HeadersEqual(expected, actual http.Header, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HeadersEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// HeaderContains asserts that actual contains each of the headers in
+// expected, and may contain others. Keys are compared without regard to case.
+// For headers which are unordered sets, such as Vary, the expected values
+// need only be among the actual values; other headers must have exactly the
+// expected values, in order. On failure, a diff of only the differing headers
+// is shown.
+func HeaderContains(t TestingT, expected, actual http.Header, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, act := canonicalHeader(expected), canonicalHeader(actual)
+	var differ []string
+	for key, e := range exp {
+		a, ok := act[key]
+		switch {
+		case !ok:
+			differ = append(differ, key)
+		case setValuedHeaders[key]:
+			if !containsAll(a, e) {
+				differ = append(differ, key)
+			}
+		case !stringsEqual(e, a):
+			differ = append(differ, key)
+		}
+	}
+	if len(differ) == 0 {
+		return true
+	}
+	return failHeaders(t, "Headers not contained", differ, exp, act, msgAndArgs...)
+}
+
+// HeaderContains asserts that actual contains each of the headers in
+// expected, and may contain others. Keys are compared without regard to case.
+// For headers which are unordered sets, such as Vary, the expected values
+// need only be among the actual values; other headers must have exactly the
+// expected values, in order. On failure, a diff of only the differing headers
+// is shown.
+func (a *Assertions) HeaderContains(expected, actual http.Header, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HeaderContains(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// failHeaders reports a failure of the headers named by keys, with a diff of
+// those headers only.
+func failHeaders(t TestingT, failureMessage string, keys []string, expected, actual http.Header, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	sort.Strings(keys)
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, failureMessage+": "+strings.Join(keys, ", "),
+		formatHeader(expected, keys), formatHeader(actual, keys), o, msgAndArgs...)
+}
+
+// canonicalHeader returns a copy of h with canonical keys. The values of
+// set-valued headers are split at commas and sorted.
+func canonicalHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for key, values := range h {
+		key = http.CanonicalHeaderKey(key)
+		c[key] = append(c[key], values...)
+	}
+	for key, values := range c {
+		if !setValuedHeaders[key] {
+			continue
+		}
+		var tokens []string
+		for _, v := range values {
+			for _, token := range strings.Split(v, ",") {
+				if token = strings.TrimSpace(token); token != "" {
+					tokens = append(tokens, token)
+				}
+			}
+		}
+		sort.Strings(tokens)
+		c[key] = tokens
+	}
+	return c
+}
+
+// formatHeader renders the headers of h named by keys, one line per value, as
+// they would appear in a request or response.
+func formatHeader(h http.Header, keys []string) string {
+	buf := &bytes.Buffer{}
+	for _, key := range keys {
+		for _, v := range h[key] {
+			fmt.Fprintf(buf, "%s: %s\n", key, v)
+		}
+	}
+	return buf.String()
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAll reports whether tokens contains each of wanted, as many times
+// as it appears in wanted.
+func containsAll(tokens, wanted []string) bool {
+	counts := make(map[string]int, len(tokens))
+	for _, t := range tokens {
+		counts[t]++
+	}
+	for _, w := range wanted {
+		if counts[w] == 0 {
+			return false
+		}
+		counts[w]--
+	}
+	return true
+}
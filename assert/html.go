@@ -0,0 +1,250 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// multiValuedAttrs are attributes whose values are unordered sets of
+// space-separated tokens.
+var multiValuedAttrs = map[string]bool{
+	"class":     true,
+	"rel":       true,
+	"accesskey": true,
+	"headers":   true,
+}
+
+// htmlNormalization selects the normalizations applied by normalizeHTML.
+type htmlNormalization struct {
+	// whitespace collapses runs of whitespace in text nodes, and drops
+	// whitespace-only text nodes.
+	whitespace bool
+	// attributes sorts attributes by name, and sorts the tokens of
+	// multi-valued attributes such as class.
+	attributes bool
+	// comments drops comment nodes.
+	comments bool
+}
+
+// WithIgnoreHTMLWhitespace causes HTMLEqualStrict to collapse runs of
+// whitespace in text nodes, and to drop whitespace-only text nodes, such as
+// indentation, before comparing. HTMLEqual always does so.
+func WithIgnoreHTMLWhitespace() Option {
+	return func(o *options) {
+		o.htmlWhitespace = true
+	}
+}
+
+// WithIgnoreHTMLAttributeOrder causes HTMLEqualStrict to sort the attributes
+// of each element, and the tokens of multi-valued attributes such as class,
+// before comparing. HTMLEqual always does so.
+func WithIgnoreHTMLAttributeOrder() Option {
+	return func(o *options) {
+		o.htmlAttributeOrder = true
+	}
+}
+
+// WithIgnoreHTMLComments causes HTMLEqual and HTMLEqualStrict to drop all
+// comment nodes from both documents before comparing.
+func WithIgnoreHTMLComments() Option {
+	return func(o *options) {
+		o.htmlComments = true
+	}
+}
+
+// WithXHTML causes HTMLEqual and HTMLEqualStrict to require that both
+// documents be well-formed XML, failing on any document which relies on the
+// error recovery of the forgiving HTML5 parser, such as unclosed or misnested
+// tags, unquoted attributes, or undeclared entities. Documents given as
+// *html.Node objects or goquery selections have already been parsed, and are
+// not checked.
+func WithXHTML() Option {
+	return func(o *options) {
+		o.xhtml = true
+	}
+}
+
+// checkXHTML returns an error if the document i, given as a string or []byte,
+// is not well-formed XML.
+func checkXHTML(i interface{}) error {
+	var r io.Reader
+	switch v := i.(type) {
+	case string:
+		r = strings.NewReader(v)
+	case []byte:
+		r = bytes.NewReader(v)
+	default:
+		return nil
+	}
+	dec := xml.NewDecoder(r)
+	for {
+		if _, err := dec.Token(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// htmlNormalization returns the normalizations selected by o. Unless strict is
+// true, whitespace and attributes are always normalized.
+func (o *options) htmlNormalization(strict bool) htmlNormalization {
+	return htmlNormalization{
+		whitespace: !strict || o.htmlWhitespace,
+		attributes: !strict || o.htmlAttributeOrder,
+		comments:   o.htmlComments,
+	}
+}
+
+// normalizeHTML returns a normalized copy of n. Depending on norm, attributes
+// are sorted by name, the tokens of multi-valued attributes such as class are
+// sorted, runs of whitespace in text nodes are collapsed, whitespace-only
+// text nodes are dropped, and comments are dropped. Text within elements
+// where whitespace is significant, such as <pre>, is left untouched.
+func normalizeHTML(n *html.Node, norm htmlNormalization) *html.Node {
+	return normalizeHTMLNode(n, norm, false)
+}
+
+func normalizeHTMLNode(n *html.Node, norm htmlNormalization, preserveSpace bool) *html.Node {
+	c := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+	}
+	if len(n.Attr) > 0 {
+		c.Attr = make([]html.Attribute, len(n.Attr))
+		copy(c.Attr, n.Attr)
+	}
+	if norm.attributes && len(c.Attr) > 0 {
+		for i, attr := range c.Attr {
+			if multiValuedAttrs[attr.Key] && attr.Namespace == "" {
+				tokens := strings.Fields(attr.Val)
+				sort.Strings(tokens)
+				c.Attr[i].Val = strings.Join(tokens, " ")
+			}
+		}
+		sort.SliceStable(c.Attr, func(i, j int) bool {
+			if c.Attr[i].Namespace != c.Attr[j].Namespace {
+				return c.Attr[i].Namespace < c.Attr[j].Namespace
+			}
+			return c.Attr[i].Key < c.Attr[j].Key
+		})
+	}
+	if n.Type == html.ElementNode && preservesSpace(n) {
+		preserveSpace = true
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.CommentNode && norm.comments {
+			continue
+		}
+		if child.Type == html.TextNode && norm.whitespace && !preserveSpace {
+			text := strings.Join(strings.Fields(child.Data), " ")
+			if text == "" {
+				continue
+			}
+			c.AppendChild(&html.Node{Type: html.TextNode, Data: text})
+			continue
+		}
+		c.AppendChild(normalizeHTMLNode(child, norm, preserveSpace))
+	}
+	return c
+}
+
+func preservesSpace(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Pre, atom.Textarea, atom.Script, atom.Style:
+		return true
+	}
+	return false
+}
+
+// voidElements are elements which have no closing tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "keygen": true, "link": true,
+	"meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// NormalizeHTML returns doc, a string, []byte, *html.Node, or goquery
+// selection, normalized as by HTMLEqual, and rendered with each element on a
+// line of its own. Of opts, only WithIgnoreHTMLComments has any effect.
+// Normalizing the result again returns it unchanged, which makes it suitable
+// for storing in golden files.
+func NormalizeHTML(doc interface{}, opts ...Option) (string, error) {
+	n, err := toHTMLNode(doc)
+	if err != nil {
+		return "", err
+	}
+	o, _ := parseOptions(nil)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return renderHTMLIndented(normalizeHTML(n, o.htmlNormalization(false))), nil
+}
+
+// renderHTMLIndented renders n with each element, text node and comment on a
+// line of its own, indented according to depth, so that a line-by-line diff
+// of two documents is meaningful.
+func renderHTMLIndented(n *html.Node) string {
+	buf := &bytes.Buffer{}
+	renderHTMLNode(buf, n, 0)
+	return buf.String()
+}
+
+func renderHTMLNode(buf *bytes.Buffer, n *html.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch n.Type {
+	case html.DocumentNode:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			renderHTMLNode(buf, c, depth)
+		}
+		return
+	case html.DoctypeNode:
+		fmt.Fprintf(buf, "%s<!DOCTYPE %s>\n", indent, n.Data)
+		return
+	case html.CommentNode:
+		fmt.Fprintf(buf, "%s<!--%s-->\n", indent, n.Data)
+		return
+	case html.TextNode:
+		for _, line := range strings.Split(html.EscapeString(n.Data), "\n") {
+			fmt.Fprintf(buf, "%s%s\n", indent, line)
+		}
+		return
+	case html.ElementNode:
+		if preservesSpace(n) {
+			// Indenting the contents of these elements would change them, so
+			// they are rendered verbatim.
+			buf.WriteString(indent)
+			html.Render(buf, n)
+			buf.WriteString("\n")
+			return
+		}
+	default:
+		return
+	}
+	buf.WriteString(indent + "<" + n.Data)
+	for _, attr := range n.Attr {
+		key := attr.Key
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+		fmt.Fprintf(buf, " %s=\"%s\"", key, html.EscapeString(attr.Val))
+	}
+	buf.WriteString(">\n")
+	if voidElements[n.Data] {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderHTMLNode(buf, c, depth+1)
+	}
+	fmt.Fprintf(buf, "%s</%s>\n", indent, n.Data)
+}
@@ -0,0 +1,143 @@
+package assert
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLContains asserts that the HTML fragment needle appears, structurally,
+// somewhere within the haystack document: some element of haystack must have
+// a run of consecutive children equivalent to the nodes of needle. Both are
+// normalized as by HTMLEqual, and the same options are accepted. Accepts
+// strings, byte arrays, *html.Node objects, or goquery selection. On failure,
+// needle is diffed against the most similar part of haystack.
+func HTMLContains(t TestingT, haystack, needle interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	doc, err := toHTMLNode(haystack)
+	if err != nil {
+		return Fail(t, "invalid haystack document: "+err.Error(), msgAndArgs...)
+	}
+	fragment, err := htmlFragment(needle)
+	if err != nil {
+		return Fail(t, "invalid needle fragment: "+err.Error(), msgAndArgs...)
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	norm := o.htmlNormalization(false)
+	nodes := normalizeHTMLNodes(fragment, norm)
+	if len(nodes) == 0 {
+		return Fail(t, "Needle fragment is empty", msgAndArgs...)
+	}
+	want := renderHTMLNodes(nodes)
+	root := normalizeHTML(doc, norm)
+	if eachHTMLWindow(root, len(nodes), func(got string) bool { return got == want }) {
+		return true
+	}
+	// The closest part of haystack is sought only once the search has failed,
+	// as comparing the similarity of every candidate is costly.
+	var closest string
+	best := -1.0
+	eachHTMLWindow(root, len(nodes), func(got string) bool {
+		if ratio := lineSimilarity(want, got); ratio > best {
+			best, closest = ratio, got
+		}
+		return false
+	})
+	return failDiff(t, "HTML does not contain expected fragment", want, closest, o, msgAndArgs...)
+}
+
+// HTMLContains asserts that the HTML fragment needle appears, structurally,
+// somewhere within the haystack document: some element of haystack must have
+// a run of consecutive children equivalent to the nodes of needle. Both are
+// normalized as by HTMLEqual, and the same options are accepted. Accepts
+// strings, byte arrays, *html.Node objects, or goquery selection. On failure,
+// needle is diffed against the most similar part of haystack.
+func (a *Assertions) HTMLContains(haystack, needle interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLContains(a.t, haystack, needle, a.withOptions(msgAndArgs)...)
+}
+
+// eachHTMLWindow calls fn with the rendering of each run of size consecutive
+// children of n and of its descendants, until fn returns true. It reports
+// whether fn did so.
+func eachHTMLWindow(n *html.Node, size int, fn func(got string) bool) bool {
+	var children []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		children = append(children, c)
+	}
+	for i := 0; i+size <= len(children); i++ {
+		if fn(renderHTMLNodes(children[i : i+size])) {
+			return true
+		}
+	}
+	for _, c := range children {
+		if eachHTMLWindow(c, size, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlFragment returns the top-level nodes of the HTML fragment i. Strings
+// and byte slices are parsed in the context of a <body> element.
+func htmlFragment(i interface{}) ([]*html.Node, error) {
+	var src string
+	switch v := i.(type) {
+	case string:
+		src = v
+	case []byte:
+		src = string(v)
+	case *html.Node:
+		if v.Type != html.DocumentNode {
+			return []*html.Node{v}, nil
+		}
+		return goquery.NewDocumentFromNode(v).Find("body").Contents().Nodes, nil
+	case *goquery.Selection:
+		return v.Nodes, nil
+	default:
+		return nil, errors.Errorf("unknown type: %T", i)
+	}
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	return html.ParseFragment(strings.NewReader(src), body)
+}
+
+// normalizeHTMLNodes normalizes each of nodes as siblings, dropping any which
+// normalization removes entirely, such as whitespace-only text.
+func normalizeHTMLNodes(nodes []*html.Node, norm htmlNormalization) []*html.Node {
+	parent := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	for _, n := range nodes {
+		c := *n
+		c.Parent, c.PrevSibling, c.NextSibling = nil, nil, nil
+		parent.AppendChild(&c)
+	}
+	normalized := normalizeHTML(parent, norm)
+	var result []*html.Node
+	for c := normalized.FirstChild; c != nil; c = c.NextSibling {
+		result = append(result, c)
+	}
+	return result
+}
+
+// renderHTMLNodes renders each of nodes as by renderHTMLIndented.
+func renderHTMLNodes(nodes []*html.Node) string {
+	buf := &bytes.Buffer{}
+	for _, n := range nodes {
+		renderHTMLNode(buf, n, 0)
+	}
+	return buf.String()
+}
+
+// lineSimilarity returns the difflib similarity ratio of the lines of a and
+// b.
+func lineSimilarity(a, b string) float64 {
+	return difflib.NewMatcher(difflib.SplitLines(a), difflib.SplitLines(b)).Ratio()
+}
@@ -0,0 +1,50 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLContains(t *testing.T) {
+	const haystack = `<html><body>
+<nav><a href="/">Home</a></nav>
+<main><h1>Title</h1><p class="lead  intro">Hello, <b>world</b></p><p>Bye</p></main>
+</body></html>`
+	tests := []struct {
+		name   string
+		needle string
+		want   string
+	}{
+		{name: "element", needle: `<a href="/">Home</a>`},
+		{name: "normalized", needle: `<p class="intro lead">Hello,  <b>world</b></p>`},
+		{name: "consecutive siblings", needle: `<h1>Title</h1><p class="lead intro">Hello, <b>world</b></p>`},
+		{name: "text", needle: `Bye`},
+		{
+			name:   "differing text",
+			needle: `<p>Goodbye</p>`,
+			want:   "+  Bye",
+		},
+		{
+			name:   "siblings out of order",
+			needle: `<p>Bye</p><h1>Title</h1>`,
+			want:   "HTML does not contain expected fragment",
+		},
+		{
+			name:   "empty needle",
+			needle: " ",
+			want:   "Needle fragment is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := HTMLContains(m, haystack, tt.needle)
+			if got != (tt.want == "") {
+				t.Fatalf("HTMLContains returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
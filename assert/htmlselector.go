@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/pkg/errors"
+)
+
+// HTMLSelectionEqual asserts that the elements matched by the CSS selector in
+// each of the two documents represent equivalent HTML. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. Only the matched elements
+// are compared, with the same normalization, and accepting the same options,
+// as HTMLEqual, so the rest of each page may differ freely.
+func HTMLSelectionEqual(t TestingT, selector string, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	matcher, err := cascadia.Compile(selector)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid selector %q: %s", selector, err), msgAndArgs...)
+	}
+	exp, err := selectHTML(expected, matcher)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid expected document: %s", err), msgAndArgs...)
+	}
+	act, err := selectHTML(actual, matcher)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid actual document: %s", err), msgAndArgs...)
+	}
+	if exp.Length() == 0 {
+		return Fail(t, fmt.Sprintf("Selector %q matches nothing in expected document", selector), msgAndArgs...)
+	}
+	if exp.Length() != act.Length() {
+		return Fail(t, fmt.Sprintf("Selector %q matches %d elements in expected document, but %d in actual document",
+			selector, exp.Length(), act.Length()), msgAndArgs...)
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	if o.xhtml {
+		if err := checkXHTML(expected); err != nil {
+			return Fail(t, fmt.Sprintf("Expected document is not well-formed XHTML: %s", err), msgAndArgs...)
+		}
+		if err := checkXHTML(actual); err != nil {
+			return Fail(t, fmt.Sprintf("Actual document is not well-formed XHTML: %s", err), msgAndArgs...)
+		}
+	}
+	// The matched nodes are compared directly, rather than rendered and
+	// reparsed, as the parser would drop elements such as <tr> or <option>
+	// outside of their usual context.
+	norm := o.htmlNormalization(false)
+	expHTML := renderHTMLNodes(normalizeHTMLNodes(exp.Nodes, norm))
+	actHTML := renderHTMLNodes(normalizeHTMLNodes(act.Nodes, norm))
+	if expHTML == actHTML {
+		return true
+	}
+	return failDiff(t, "HTML differs", expHTML, actHTML, o, msgAndArgs...)
+}
+
+// HTMLSelectionEqual asserts that the elements matched by the CSS selector in
+// each of the two documents represent equivalent HTML. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. Only the matched elements
+// are compared, with the same normalization, and accepting the same options,
+// as HTMLEqual, so the rest of each page may differ freely.
+func (a *Assertions) HTMLSelectionEqual(selector string, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLSelectionEqual(a.t, selector, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// selectHTML parses the document i, and returns the elements within it which
+// match matcher.
+func selectHTML(i interface{}, matcher goquery.Matcher) (*goquery.Selection, error) {
+	doc, err := toHTMLNode(i)
+	if err != nil {
+		return nil, err
+	}
+	return goquery.NewDocumentFromNode(doc).FindMatcher(matcher), nil
+}
+
+// outerHTML returns the concatenated outer HTML of each element of s.
+func outerHTML(s *goquery.Selection) (string, error) {
+	var buf strings.Builder
+	for _, n := range s.Nodes {
+		str, err := goquery.OuterHtml(goquery.NewDocumentFromNode(n).Selection)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get outer html")
+		}
+		buf.WriteString(str)
+	}
+	return buf.String(), nil
+}
+
+// HTMLAttrEqual asserts that the element of doc matched by the CSS selector
+// has the attribute attr, with the value expected. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The selector must match
+// exactly one element. On failure, the outer HTML of the element is reported.
+func HTMLAttrEqual(t TestingT, doc interface{}, selector, attr, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	matcher, err := cascadia.Compile(selector)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid selector %q: %s", selector, err), msgAndArgs...)
+	}
+	s, err := selectHTML(doc, matcher)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid document: %s", err), msgAndArgs...)
+	}
+	if s.Length() != 1 {
+		return Fail(t, fmt.Sprintf("Selector %q matches %d elements, rather than exactly one", selector, s.Length()), msgAndArgs...)
+	}
+	element, err := outerHTML(s)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid document: %s", err), msgAndArgs...)
+	}
+	actual, ok := s.Attr(attr)
+	if !ok {
+		return Fail(t, fmt.Sprintf("Element %s has no attribute %q:\nelement : %s", selector, attr, element), msgAndArgs...)
+	}
+	if actual == expected {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Attribute %q of element %s differs:\nexpected: %q\nactual  : %q\nelement : %s",
+		attr, selector, expected, actual, element), msgAndArgs...)
+}
+
+// HTMLAttrEqual asserts that the element of doc matched by the CSS selector
+// has the attribute attr, with the value expected. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The selector must match
+// exactly one element. On failure, the outer HTML of the element is reported.
+func (a *Assertions) HTMLAttrEqual(doc interface{}, selector, attr, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLAttrEqual(a.t, doc, selector, attr, expected, a.withOptions(msgAndArgs)...)
+}
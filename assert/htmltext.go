@@ -0,0 +1,125 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// WithHTMLSelector causes HTMLTextEqual to compare only the text of the
+// elements matched by the CSS selector in each document.
+func WithHTMLSelector(selector string) Option {
+	return func(o *options) {
+		o.htmlSelector = selector
+	}
+}
+
+// HTMLTextEqual asserts that the two arguments have the same visible text,
+// ignoring markup entirely. Accepts strings, byte arrays, *html.Node objects,
+// or goquery selection. The text of each document is extracted with hidden
+// elements, scripts and styles omitted, and with each block-level element and
+// <br> starting a new line; whitespace within each line is collapsed, and
+// blank lines are dropped. On failure, a line-by-line diff of the text is
+// shown. The WithHTMLSelector option limits the comparison to part of each
+// document.
+func HTMLTextEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	var matcher goquery.Matcher
+	if o.htmlSelector != "" {
+		sel, err := cascadia.Compile(o.htmlSelector)
+		if err != nil {
+			return Fail(t, fmt.Sprintf("Invalid selector %q: %s", o.htmlSelector, err), msgAndArgs...)
+		}
+		matcher = sel
+	}
+	exp, err := htmlText(expected, matcher)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid expected document: %s", err), msgAndArgs...)
+	}
+	act, err := htmlText(actual, matcher)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid actual document: %s", err), msgAndArgs...)
+	}
+	if exp == act {
+		return true
+	}
+	return failDiff(t, "HTML text differs", exp, act, o, msgAndArgs...)
+}
+
+// HTMLTextEqual asserts that the two arguments have the same visible text,
+// ignoring markup entirely. Accepts strings, byte arrays, *html.Node objects,
+// or goquery selection. The text of each document is extracted with hidden
+// elements, scripts and styles omitted, and with each block-level element and
+// <br> starting a new line; whitespace within each line is collapsed, and
+// blank lines are dropped. On failure, a line-by-line diff of the text is
+// shown. The WithHTMLSelector option limits the comparison to part of each
+// document.
+func (a *Assertions) HTMLTextEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLTextEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// htmlText returns the visible text of the document i, one line per block,
+// or of the elements matched by matcher, if it is not nil.
+func htmlText(i interface{}, matcher goquery.Matcher) (string, error) {
+	doc, err := toHTMLNode(i)
+	if err != nil {
+		return "", err
+	}
+	s := goquery.NewDocumentFromNode(doc).Selection
+	if matcher != nil {
+		s = s.FindMatcher(matcher)
+	}
+	x := &textExtractor{}
+	s.Each(func(_ int, c *goquery.Selection) {
+		x.extract(c)
+		x.flush()
+	})
+	return strings.Join(x.lines, "\n"), nil
+}
+
+// textExtractor accumulates the visible text of a document as lines.
+type textExtractor struct {
+	lines []string
+	line  strings.Builder
+}
+
+func (x *textExtractor) flush() {
+	if text := collapseSpace(x.line.String()); text != "" {
+		x.lines = append(x.lines, text)
+	}
+	x.line.Reset()
+}
+
+func (x *textExtractor) extract(s *goquery.Selection) {
+	s.Contents().Each(func(_ int, c *goquery.Selection) {
+		node := c.Get(0)
+		switch node.Type {
+		case html.TextNode:
+			x.line.WriteString(node.Data)
+		case html.ElementNode:
+			name := goquery.NodeName(c)
+			switch {
+			case axHidden(c):
+			case name == "br":
+				x.flush()
+			case name == "img":
+				x.line.WriteString(c.AttrOr("alt", ""))
+			case inlineElements[name] || name == "a":
+				x.extract(c)
+			default:
+				x.flush()
+				x.extract(c)
+				x.flush()
+			}
+		}
+	})
+}
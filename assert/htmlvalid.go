@@ -0,0 +1,237 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+// optionalEndTags lists the elements whose end tags may be omitted.
+var optionalEndTags = map[string]bool{
+	"html": true, "head": true, "body": true, "p": true, "li": true,
+	"dt": true, "dd": true, "option": true, "optgroup": true, "rb": true,
+	"rp": true, "rt": true, "rtc": true, "tr": true, "td": true, "th": true,
+	"thead": true, "tbody": true, "tfoot": true, "colgroup": true,
+	"caption": true,
+}
+
+// paragraphClosers lists the elements whose start tags implicitly close an
+// open <p> element.
+var paragraphClosers = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"details": true, "dialog": true, "div": true, "dl": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hgroup": true, "hr": true, "main": true,
+	"menu": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "ul": true,
+}
+
+// scopeBoundaries lists the elements beyond which an open <p> is not
+// implicitly closed.
+var scopeBoundaries = map[string]bool{
+	"applet": true, "button": true, "caption": true, "html": true,
+	"marquee": true, "object": true, "table": true, "td": true,
+	"template": true, "th": true,
+}
+
+// selfNesting lists the elements which may not contain another element of
+// the same name.
+var selfNesting = map[string]bool{
+	"a": true, "button": true, "form": true,
+}
+
+// HTMLValid asserts that doc, which may be a string, a []byte, or an
+// io.Reader, is structurally valid HTML, which the HTML5 parser does not need
+// to repair. Reported problems include elements left unclosed, end tags which
+// close no open element (such as the </p> of a <p> implicitly closed by a
+// <div> within it), misnested elements, elements which may not contain
+// themselves (such as an <a> within another <a>), and duplicate id
+// attributes. As in the HTML5 parser, the / of a self-closing tag such as
+// <div/> is ignored, other than for void elements and SVG or MathML content,
+// so such an element must still be closed. Each problem is reported with its
+// line number.
+func HTMLValid(t TestingT, doc interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	src, err := htmlSource(doc)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid document: %s", err), msgAndArgs...)
+	}
+	problems := htmlProblems(src)
+	if len(problems) == 0 {
+		return true
+	}
+	return Fail(t, "HTML is not valid:\n"+strings.Join(problems, "\n"), msgAndArgs...)
+}
+
+// HTMLValid asserts that doc, which may be a string, a []byte, or an
+// io.Reader, is structurally valid HTML, which the HTML5 parser does not need
+// to repair. Reported problems include elements left unclosed, end tags which
+// close no open element (such as the </p> of a <p> implicitly closed by a
+// <div> within it), misnested elements, elements which may not contain
+// themselves (such as an <a> within another <a>), and duplicate id
+// attributes. As in the HTML5 parser, the / of a self-closing tag such as
+// <div/> is ignored, other than for void elements and SVG or MathML content,
+// so such an element must still be closed. Each problem is reported with its
+// line number.
+func (a *Assertions) HTMLValid(doc interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTMLValid(a.t, doc, a.withOptions(msgAndArgs)...)
+}
+
+func htmlSource(doc interface{}) ([]byte, error) {
+	switch v := doc.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case io.Reader:
+		return ioutil.ReadAll(v)
+	}
+	return nil, errors.Errorf("unknown type: %T", doc)
+}
+
+// openElement is an element on the stack of open elements.
+type openElement struct {
+	name string
+	line int
+	// selfClosing is set if the start tag ended in />, which is ignored
+	// for elements other than void elements and foreign content.
+	selfClosing bool
+}
+
+// tag returns the start tag of e, for use in problem descriptions.
+func (e openElement) tag() string {
+	if e.selfClosing {
+		return "<" + e.name + "/>"
+	}
+	return "<" + e.name + ">"
+}
+
+// inForeignContent reports whether the innermost open element is within an
+// <svg> or <math> element, in which a start tag may be self-closing. An
+// HTML <foreignObject> within an <svg> reverts to HTML content.
+func inForeignContent(stack []openElement) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].name {
+		case "foreignobject":
+			return false
+		case "svg", "math":
+			return true
+		}
+	}
+	return false
+}
+
+// htmlProblems tokenizes src, and returns a description of each structural
+// problem found.
+func htmlProblems(src []byte) []string {
+	var problems []string
+	report := func(line int, format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("line %d: %s", line, fmt.Sprintf(format, args...)))
+	}
+	var stack []openElement
+	ids := make(map[string]int)
+	// closedParagraph describes the most recent implicit closing of a <p>
+	// element, which explains an otherwise puzzling stray </p>.
+	var closedParagraph string
+	line := 1
+	z := html.NewTokenizer(bytes.NewReader(src))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				report(line, "%s", z.Err())
+			}
+			break
+		}
+		raw := z.Raw()
+		tokenLine := line
+		line += bytes.Count(raw, []byte("\n"))
+		tok := z.Token()
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name := tok.Data
+			for _, attr := range tok.Attr {
+				if attr.Key != "id" {
+					continue
+				}
+				if first, ok := ids[attr.Val]; ok {
+					report(tokenLine, "duplicate id %q, first used on line %d", attr.Val, first)
+					continue
+				}
+				ids[attr.Val] = tokenLine
+			}
+			if paragraphClosers[name] {
+				if i := openParagraph(stack); i >= 0 {
+					closedParagraph = fmt.Sprintf("; <p> opened on line %d was implicitly closed by <%s> on line %d",
+						stack[i].line, name, tokenLine)
+					stack = stack[:i]
+				}
+			}
+			if selfNesting[name] {
+				for _, e := range stack {
+					if e.name == name {
+						report(tokenLine, "<%s> is not allowed inside <%s> opened on line %d", name, name, e.line)
+						break
+					}
+				}
+			}
+			selfClosing := tt == html.SelfClosingTagToken
+			if voidElements[name] || selfClosing && (name == "svg" || name == "math" || inForeignContent(stack)) {
+				continue
+			}
+			stack = append(stack, openElement{name: name, line: tokenLine, selfClosing: selfClosing})
+		case html.EndTagToken:
+			name := tok.Data
+			i := len(stack) - 1
+			for i >= 0 && stack[i].name != name {
+				i--
+			}
+			if i < 0 {
+				switch {
+				case name == "p":
+					report(tokenLine, "end tag </p> does not close any open element%s", closedParagraph)
+				case !optionalEndTags[name]:
+					report(tokenLine, "end tag </%s> does not close any open element", name)
+				}
+				continue
+			}
+			for _, e := range stack[i+1:] {
+				if !optionalEndTags[e.name] {
+					report(tokenLine, "%s opened on line %d is not closed before </%s>", e.tag(), e.line, name)
+				}
+			}
+			stack = stack[:i]
+		}
+	}
+	for _, e := range stack {
+		if !optionalEndTags[e.name] {
+			report(line, "%s opened on line %d is never closed", e.tag(), e.line)
+		}
+	}
+	return problems
+}
+
+// openParagraph returns the index in stack of an open <p> element which is in
+// scope, or -1 if there is none.
+func openParagraph(stack []openElement) int {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch {
+		case stack[i].name == "p":
+			return i
+		case scopeBoundaries[stack[i].name]:
+			return -1
+		}
+	}
+	return -1
+}
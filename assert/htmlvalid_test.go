@@ -0,0 +1,64 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHTMLProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "valid",
+			doc:  "<html><body><p>a<p>b<ul><li>c</ul></body></html>",
+		},
+		{
+			name: "void elements",
+			doc:  "<body><br><br/><img src=x /></body>",
+		},
+		{
+			name: "self-closing foreign content",
+			doc:  `<body><svg><path d="M0 0"/><circle r="1"/></svg><math><mi/></math><svg/></body>`,
+		},
+		{
+			name: "self-closing div",
+			doc:  "<body><div/><p>x</p></body>",
+			want: []string{"line 1: <div/> opened on line 1 is not closed before </body>"},
+		},
+		{
+			name: "self-closing div at end of document",
+			doc:  "<div/>",
+			want: []string{"line 1: <div/> opened on line 1 is never closed"},
+		},
+		{
+			name: "self-closing HTML within foreignObject",
+			doc:  "<svg><foreignObject><span/></foreignObject></svg>",
+			want: []string{"line 1: <span/> opened on line 1 is not closed before </foreignobject>"},
+		},
+		{
+			name: "unclosed element",
+			doc:  "<div>\n<span>x</div>",
+			want: []string{"line 2: <span> opened on line 2 is not closed before </div>"},
+		},
+		{
+			name: "stray end tag",
+			doc:  "<div></span></div>",
+			want: []string{"line 1: end tag </span> does not close any open element"},
+		},
+		{
+			name: "duplicate id",
+			doc:  "<div id=a></div>\n<div id=a></div>",
+			want: []string{`line 2: duplicate id "a", first used on line 1`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlProblems([]byte(tt.doc)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
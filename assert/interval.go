@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IntervalEqual asserts that the interval from actualStart to actualEnd
+// matches the interval from expectedStart to expectedEnd, with each boundary
+// within tolerance of its expected value. On failure, each boundary which
+// differs is reported.
+func IntervalEqual(t TestingT, expectedStart, expectedEnd, actualStart, actualEnd time.Time, tolerance time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var problems []string
+	if p := boundaryDifference("Start", expectedStart, actualStart, tolerance); p != "" {
+		problems = append(problems, p)
+	}
+	if p := boundaryDifference("End", expectedEnd, actualEnd, tolerance); p != "" {
+		problems = append(problems, p)
+	}
+	if len(problems) == 0 {
+		return true
+	}
+	return Fail(t, "Intervals differ\n"+strings.Join(problems, "\n"), msgAndArgs...)
+}
+
+// IntervalEqual asserts that the interval from actualStart to actualEnd
+// matches the interval from expectedStart to expectedEnd, with each boundary
+// within tolerance of its expected value. On failure, each boundary which
+// differs is reported.
+func (a *Assertions) IntervalEqual(expectedStart, expectedEnd, actualStart, actualEnd time.Time, tolerance time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IntervalEqual(a.t, expectedStart, expectedEnd, actualStart, actualEnd, tolerance, a.withOptions(msgAndArgs)...)
+}
+
+// IntervalsOverlap asserts that the interval from startA to endA overlaps the
+// interval from startB to endB. Intervals which are separated by a gap no
+// greater than tolerance are considered to overlap.
+func IntervalsOverlap(t TestingT, startA, endA, startB, endB time.Time, tolerance time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var gap time.Duration
+	switch {
+	case endA.Before(startB):
+		gap = startB.Sub(endA)
+	case endB.Before(startA):
+		gap = startA.Sub(endB)
+	}
+	if gap <= tolerance {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Intervals do not overlap; gap of %s exceeds tolerance of %s\n"+
+		"  first:  %s - %s\n"+
+		"  second: %s - %s",
+		gap, tolerance,
+		formatTime(startA), formatTime(endA),
+		formatTime(startB), formatTime(endB)), msgAndArgs...)
+}
+
+// IntervalsOverlap asserts that the interval from startA to endA overlaps the
+// interval from startB to endB. Intervals which are separated by a gap no
+// greater than tolerance are considered to overlap.
+func (a *Assertions) IntervalsOverlap(startA, endA, startB, endB time.Time, tolerance time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IntervalsOverlap(a.t, startA, endA, startB, endB, tolerance, a.withOptions(msgAndArgs)...)
+}
+
+// boundaryDifference describes how actual differs from expected, or returns
+// an empty string if they are within tolerance.
+func boundaryDifference(name string, expected, actual time.Time, tolerance time.Duration) string {
+	delta := actual.Sub(expected)
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta <= tolerance {
+		return ""
+	}
+	return fmt.Sprintf("%s differs by %s, exceeding tolerance of %s\n"+
+		"  expected: %s\n"+
+		"  actual:   %s",
+		name, delta, tolerance, formatTime(expected), formatTime(actual))
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
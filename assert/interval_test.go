@@ -0,0 +1,137 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIntervalEqual(t *testing.T) {
+	start := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	tests := []struct {
+		name                   string
+		actualStart, actualEnd time.Time
+		tolerance              time.Duration
+		want                   string
+	}{
+		{
+			name:        "equal",
+			actualStart: start,
+			actualEnd:   end,
+		},
+		{
+			name:        "offsets within tolerance",
+			actualStart: start.Add(500 * time.Millisecond),
+			actualEnd:   end.Add(-time.Second),
+			tolerance:   time.Second,
+		},
+		{
+			name:        "same instant in another zone",
+			actualStart: start.In(time.FixedZone("UTC+1", 3600)),
+			actualEnd:   end,
+		},
+		{
+			name:        "start outside tolerance",
+			actualStart: start.Add(-2 * time.Second),
+			actualEnd:   end,
+			tolerance:   time.Second,
+			want:        "Intervals differ\nStart differs by 2s, exceeding tolerance of 1s\n  expected: 2020-01-01T10:00:00Z\n  actual:   2020-01-01T09:59:58Z",
+		},
+		{
+			name:        "end outside tolerance",
+			actualStart: start,
+			actualEnd:   end.Add(1001 * time.Millisecond),
+			tolerance:   time.Second,
+			want:        "Intervals differ\nEnd differs by 1.001s, exceeding tolerance of 1s\n  actual:   2020-01-01T11:00:01.001Z",
+		},
+		{
+			name:        "both outside tolerance",
+			actualStart: start.Add(time.Minute),
+			actualEnd:   end.Add(time.Minute),
+			tolerance:   time.Second,
+			want:        "Start differs by 1m0s\nEnd differs by 1m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := IntervalEqual(m, start, end, tt.actualStart, tt.actualEnd, tt.tolerance)
+			if got != (tt.want == "") {
+				t.Fatalf("IntervalEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestIntervalsOverlap(t *testing.T) {
+	start := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	tests := []struct {
+		name         string
+		startB, endB time.Time
+		tolerance    time.Duration
+		want         string
+	}{
+		{
+			name:   "overlapping",
+			startB: start.Add(30 * time.Minute),
+			endB:   end.Add(30 * time.Minute),
+		},
+		{
+			name:   "contained",
+			startB: start.Add(time.Minute),
+			endB:   end.Add(-time.Minute),
+		},
+		{
+			name:   "touching",
+			startB: end,
+			endB:   end.Add(time.Hour),
+		},
+		{
+			name:      "gap after within tolerance",
+			startB:    end.Add(time.Second),
+			endB:      end.Add(time.Hour),
+			tolerance: time.Second,
+		},
+		{
+			name:      "gap before within tolerance",
+			startB:    start.Add(-time.Hour),
+			endB:      start.Add(-time.Second),
+			tolerance: time.Second,
+		},
+		{
+			name:      "gap after outside tolerance",
+			startB:    end.Add(time.Minute),
+			endB:      end.Add(time.Hour),
+			tolerance: time.Second,
+			want:      "Intervals do not overlap; gap of 1m0s exceeds tolerance of 1s\n  first:  2020-01-01T10:00:00Z - 2020-01-01T11:00:00Z\n  second: 2020-01-01T11:01:00Z - 2020-01-01T12:00:00Z",
+		},
+		{
+			name:      "gap before outside tolerance",
+			startB:    start.Add(-time.Hour),
+			endB:      start.Add(-2 * time.Second),
+			tolerance: time.Second,
+			want:      "gap of 2s exceeds tolerance of 1s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := IntervalsOverlap(m, start, end, tt.startB, tt.endB, tt.tolerance)
+			if got != (tt.want == "") {
+				t.Fatalf("IntervalsOverlap returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package assert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// parseJSON5 parses a JSON5 document into a generic value, of the same form
+// produced by json.Unmarshal into an interface{}. JSON5 extends JSON with
+// comments, trailing commas, unquoted object keys, single-quoted strings, and
+// more flexible number formats. Infinity and NaN are rejected, as they
+// cannot be represented in JSON.
+func parseJSON5(data []byte) (interface{}, error) {
+	p := &json5Parser{data: string(data)}
+	v, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.skip(); err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.data) {
+		return nil, p.errorf("unexpected %q after value", p.data[p.pos])
+	}
+	return v, nil
+}
+
+type json5Parser struct {
+	data string
+	pos  int
+}
+
+func (p *json5Parser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(p.data[:p.pos], "\n") + 1
+	return errors.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// skip advances past whitespace and comments.
+func (p *json5Parser) skip() error {
+	for p.pos < len(p.data) {
+		r, size := utf8.DecodeRuneInString(p.data[p.pos:])
+		switch {
+		case unicode.IsSpace(r) || r == '\uFEFF':
+			p.pos += size
+		case strings.HasPrefix(p.data[p.pos:], "//"):
+			end := strings.IndexAny(p.data[p.pos:], "\n\r\u2028\u2029")
+			if end < 0 {
+				p.pos = len(p.data)
+			} else {
+				p.pos += end
+			}
+		case strings.HasPrefix(p.data[p.pos:], "/*"):
+			end := strings.Index(p.data[p.pos+2:], "*/")
+			if end < 0 {
+				return p.errorf("unterminated comment")
+			}
+			p.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *json5Parser) value() (interface{}, error) {
+	if err := p.skip(); err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of input")
+	}
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.object()
+	case c == '[':
+		return p.array()
+	case c == '"' || c == '\'':
+		return p.str()
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		return p.number()
+	}
+	ident := p.identifier()
+	switch ident {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "Infinity", "NaN":
+		return nil, p.errorf("%s cannot be represented in JSON", ident)
+	case "":
+		return nil, p.errorf("unexpected %q", p.data[p.pos])
+	}
+	return nil, p.errorf("unexpected identifier %q", ident)
+}
+
+func (p *json5Parser) object() (interface{}, error) {
+	p.pos++ // {
+	obj := map[string]interface{}{}
+	for {
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.data) && p.data[p.pos] == '}' {
+			p.pos++
+			return obj, nil
+		}
+		var key string
+		if p.pos < len(p.data) && (p.data[p.pos] == '"' || p.data[p.pos] == '\'') {
+			k, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			key = k.(string)
+		} else if key = p.identifier(); key == "" {
+			return nil, p.errorf("expected object key")
+		}
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.data) || p.data[p.pos] != ':' {
+			return nil, p.errorf("expected ':' after object key %q", key)
+		}
+		p.pos++
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = v
+		if done, err := p.separator('}'); err != nil || done {
+			return obj, err
+		}
+	}
+}
+
+func (p *json5Parser) array() (interface{}, error) {
+	p.pos++ // [
+	arr := []interface{}{}
+	for {
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.data) && p.data[p.pos] == ']' {
+			p.pos++
+			return arr, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+		if done, err := p.separator(']'); err != nil || done {
+			return arr, err
+		}
+	}
+}
+
+// separator consumes the comma following an object member or array element,
+// or the closing delimiter. It returns true if the closing delimiter was
+// consumed.
+func (p *json5Parser) separator(closing byte) (bool, error) {
+	if err := p.skip(); err != nil {
+		return false, err
+	}
+	if p.pos >= len(p.data) {
+		return false, p.errorf("unexpected end of input")
+	}
+	switch p.data[p.pos] {
+	case ',':
+		p.pos++
+		return false, nil
+	case closing:
+		p.pos++
+		return true, nil
+	}
+	return false, p.errorf("expected ',' or %q, found %q", closing, p.data[p.pos])
+}
+
+func (p *json5Parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.data) {
+		r, size := utf8.DecodeRuneInString(p.data[p.pos:])
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (p.pos > start && unicode.IsDigit(r))) {
+			break
+		}
+		p.pos += size
+	}
+	return p.data[start:p.pos]
+}
+
+func (p *json5Parser) number() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.data) && strings.IndexByte("+-.xXeE0123456789abcdefABCDEF", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+	num := p.data[start:p.pos]
+	if rest := p.identifier(); rest == "Infinity" || rest == "NaN" {
+		return nil, p.errorf("%s cannot be represented in JSON", num+rest)
+	}
+	sign := 1.0
+	switch {
+	case strings.HasPrefix(num, "-"):
+		sign, num = -1, num[1:]
+	case strings.HasPrefix(num, "+"):
+		num = num[1:]
+	}
+	if strings.HasPrefix(num, "0x") || strings.HasPrefix(num, "0X") {
+		n, err := strconv.ParseUint(num[2:], 16, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.data[start:p.pos])
+		}
+		return sign * float64(n), nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %q", p.data[start:p.pos])
+	}
+	return sign * f, nil
+}
+
+func (p *json5Parser) str() (interface{}, error) {
+	quote := p.data[p.pos]
+	p.pos++
+	buf := &strings.Builder{}
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return buf.String(), nil
+		case c == '\n' || c == '\r':
+			return nil, p.errorf("unterminated string")
+		case c != '\\':
+			buf.WriteByte(c)
+			p.pos++
+			continue
+		}
+		p.pos++ // backslash
+		if p.pos >= len(p.data) {
+			break
+		}
+		esc := p.data[p.pos]
+		p.pos++
+		switch esc {
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'v':
+			buf.WriteByte('\v')
+		case '0':
+			buf.WriteByte(0)
+		case '\n':
+			// Line continuation
+		case '\r':
+			if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+				p.pos++
+			}
+		case 'x', 'u':
+			n := 2
+			if esc == 'u' {
+				n = 4
+			}
+			if p.pos+n > len(p.data) {
+				return nil, p.errorf("invalid escape sequence")
+			}
+			r, err := strconv.ParseUint(p.data[p.pos:p.pos+n], 16, 32)
+			if err != nil {
+				return nil, p.errorf("invalid escape sequence \\%c%s", esc, p.data[p.pos:p.pos+n])
+			}
+			p.pos += n
+			if utf16.IsSurrogate(rune(r)) && strings.HasPrefix(p.data[p.pos:], "\\u") && p.pos+6 <= len(p.data) {
+				if r2, err := strconv.ParseUint(p.data[p.pos+2:p.pos+6], 16, 32); err == nil {
+					if dec := utf16.DecodeRune(rune(r), rune(r2)); dec != utf8.RuneError {
+						buf.WriteRune(dec)
+						p.pos += 6
+						continue
+					}
+				}
+			}
+			buf.WriteRune(rune(r))
+		default:
+			buf.WriteByte(esc)
+		}
+	}
+	return nil, p.errorf("unterminated string")
+}
@@ -0,0 +1,140 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseJSON5(t *testing.T) {
+	type obj = map[string]interface{}
+	type arr = []interface{}
+	tests := []struct {
+		name  string
+		input string
+		want  interface{}
+		err   string
+	}{
+		{name: "plain JSON", input: `{"a": [1, "x", true, false, null]}`, want: obj{"a": arr{1.0, "x", true, false, nil}}},
+		{name: "empty containers", input: `[{}, []]`, want: arr{obj{}, arr{}}},
+		{name: "line comment", input: "// leading\n1 // trailing", want: 1.0},
+		{name: "block comment", input: "[1, /* two, */ 3]", want: arr{1.0, 3.0}},
+		{name: "trailing commas", input: "{a: [1, 2,], b: 3,}", want: obj{"a": arr{1.0, 2.0}, "b": 3.0}},
+		{name: "unquoted keys", input: "{$id: 1, _x9: 2, ünï: 3}", want: obj{"$id": 1.0, "_x9": 2.0, "ünï": 3.0}},
+		{name: "single-quoted strings", input: `{'a': 'it\'s "quoted"'}`, want: obj{"a": `it's "quoted"`}},
+		{name: "hexadecimal", input: "[0x1F, -0Xff]", want: arr{31.0, -255.0}},
+		{name: "leading and trailing decimal point", input: "[.5, 5., +1, -.25]", want: arr{0.5, 5.0, 1.0, -0.25}},
+		{name: "exponent", input: "1.5e3", want: 1500.0},
+		{name: "escapes", input: `"\b\f\n\r\t\v\0\x41\u00e9\q"`, want: "\b\f\n\r\t\v\x00Aéq"},
+		{name: "surrogate pair", input: `"\ud83d\ude00"`, want: "😀"},
+		{name: "line continuation", input: "'a\\\nb'", want: "ab"},
+		{name: "byte order mark", input: "\uFEFF{}", want: obj{}},
+		{name: "empty input", input: "", err: "line 1: unexpected end of input"},
+		{name: "unterminated object", input: "{a: 1", err: "line 1: unexpected end of input"},
+		{name: "unterminated array", input: "[1,\n2", err: "line 2: unexpected end of input"},
+		{name: "unterminated string", input: "'abc", err: "unterminated string"},
+		{name: "newline in string", input: "'a\nb'", err: "unterminated string"},
+		{name: "unterminated comment", input: "1 /* x", err: "unterminated comment"},
+		{name: "missing colon", input: "{a 1}", err: `expected ':' after object key "a"`},
+		{name: "missing key", input: "{: 1}", err: "expected object key"},
+		{name: "missing comma", input: "[1 2]", err: `expected ',' or ']', found '2'`},
+		{name: "double comma", input: "[1,,2]", err: `unexpected ','`},
+		{name: "trailing data", input: "1 2", err: `unexpected '2' after value`},
+		{name: "unknown identifier", input: "undefined", err: `unexpected identifier "undefined"`},
+		{name: "Infinity", input: "{a: Infinity}", err: "Infinity cannot be represented in JSON"},
+		{name: "negative Infinity", input: "-Infinity", err: "-Infinity cannot be represented in JSON"},
+		{name: "NaN", input: "NaN", err: "NaN cannot be represented in JSON"},
+		{name: "invalid number", input: "1.2.3", err: `invalid number "1.2.3"`},
+		{name: "invalid hexadecimal", input: "0xg", err: `invalid number "0xg"`},
+		{name: "invalid escape", input: `"\u12g4"`, err: `invalid escape sequence \u12g4`},
+		{name: "truncated escape", input: `"\u12`, err: "invalid escape sequence"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSON5([]byte(tt.input))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeepEqualJSON5(t *testing.T) {
+	fixture := []byte(`// An annotated fixture.
+{
+  id: 0x10, /* hexadecimal */
+  name: 'O\'Brien',
+  tags: ['a', "b",],
+  score: +.5,
+  nested: {ok: true, none: null,},
+}`)
+	type nested struct {
+		OK   bool        `json:"ok"`
+		None interface{} `json:"none"`
+	}
+	type record struct {
+		ID     int      `json:"id"`
+		Name   string   `json:"name"`
+		Tags   []string `json:"tags"`
+		Score  float64  `json:"score"`
+		Nested nested   `json:"nested"`
+	}
+	good := record{ID: 16, Name: "O'Brien", Tags: []string{"a", "b"}, Score: 0.5, Nested: nested{OK: true}}
+	bad := good
+	bad.ID = 17
+	tests := []struct {
+		name     string
+		expected []byte
+		actual   interface{}
+		want     string
+	}{
+		{
+			name:     "struct",
+			expected: fixture,
+			actual:   good,
+		},
+		{
+			name:     "map",
+			expected: fixture,
+			actual: map[string]interface{}{
+				"id": 16, "name": "O'Brien", "tags": []string{"a", "b"}, "score": 0.5,
+				"nested": map[string]interface{}{"ok": true, "none": nil},
+			},
+		},
+		{
+			name:     "differing value",
+			expected: fixture,
+			actual:   bad,
+			want:     "-    \"id\": 16,\n+    \"id\": 17,",
+		},
+		{
+			name:     "invalid fixture",
+			expected: []byte("{id: 16"),
+			actual:   good,
+			want:     "Error parsing expected JSON5: line 1: unexpected end of input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualJSON5(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualJSON5 returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// JSONContains asserts that the actual interface{} marshals to JSON which
+// contains the expected JSON. Every key in an expected object must be present
+// in the corresponding actual object with a matching value, but the actual
+// object may contain additional keys. Arrays are matched element by element:
+// each element of an expected array must match the element at the same index
+// of the actual array, and any trailing elements of the actual array beyond
+// the length of the expected array are ignored. With the WithUnorderedArrays
+// option, each element of an expected array may instead match any one
+// distinct element of the actual array. All other values must be equal.
+func JSONContains(t TestingT, expected []byte, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	actualJSON, ok := marshalJSON(t, "actual", actual, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	var e, a interface{}
+	if !unmarshalJSON(t, "expected", expected, &e, msgAndArgs...) {
+		return false
+	}
+	if !unmarshalJSON(t, "actual", actualJSON, &a, msgAndArgs...) {
+		return false
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	path, expSub, actSub, ok := jsonContains("", e, a, o.unorderedArrays)
+	if ok {
+		return true
+	}
+	if path == "" {
+		path = "/"
+	}
+	return failDiff(t, fmt.Sprintf("JSON does not contain expected value at %s", path),
+		indentJSON(expSub), indentJSON(actSub), o, msgAndArgs...)
+}
+
+// JSONContains asserts that the actual interface{} marshals to JSON which
+// contains the expected JSON. Every key in an expected object must be present
+// in the corresponding actual object with a matching value, but the actual
+// object may contain additional keys. Arrays are matched element by element:
+// each element of an expected array must match the element at the same index
+// of the actual array, and any trailing elements of the actual array beyond
+// the length of the expected array are ignored. With the WithUnorderedArrays
+// option, each element of an expected array may instead match any one
+// distinct element of the actual array. All other values must be equal.
+func (a *Assertions) JSONContains(expected []byte, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONContains(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// WithUnorderedArrays causes JSONContains to ignore the order of array
+// elements, matching each expected element against any one actual element.
+func WithUnorderedArrays() Option {
+	return func(o *options) {
+		o.unorderedArrays = true
+	}
+}
+
+// jsonContains reports whether actual contains expected. If it does not, it
+// returns the JSON Pointer of the first subtree which diverges, along with the
+// expected and actual values of that subtree. When a key or array element is
+// missing, the enclosing object or array is returned. When an expected element
+// of an unordered array matches no actual element, that element is returned
+// along with the whole actual array.
+func jsonContains(path string, expected, actual interface{}, unordered bool) (string, interface{}, interface{}, bool) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return path, expected, actual, false
+		}
+		keys := make([]string, 0, len(e))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			av, ok := a[key]
+			if !ok {
+				return path, expected, actual, false
+			}
+			if p, es, as, ok := jsonContains(path+"/"+escapePointer(key), e[key], av, unordered); !ok {
+				return p, es, as, false
+			}
+		}
+		return "", nil, nil, true
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) < len(e) {
+			return path, expected, actual, false
+		}
+		if unordered {
+			if i, ok := matchUnordered(e, a); !ok {
+				return fmt.Sprintf("%s/%d", path, i), e[i], actual, false
+			}
+			return "", nil, nil, true
+		}
+		for i, ev := range e {
+			if p, es, as, ok := jsonContains(fmt.Sprintf("%s/%d", path, i), ev, a[i], unordered); !ok {
+				return p, es, as, false
+			}
+		}
+		return "", nil, nil, true
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		return path, expected, actual, false
+	}
+	return "", nil, nil, true
+}
+
+// matchUnordered pairs each element of expected with a distinct element of
+// actual which contains it. If no complete pairing exists, it returns the
+// index of an expected element left unmatched.
+func matchUnordered(expected, actual []interface{}) (int, bool) {
+	matches := maxMatching(len(expected), len(actual), func(i, j int) bool {
+		_, _, _, ok := jsonContains("", expected[i], actual[j], true)
+		return ok
+	})
+	for i, j := range matches {
+		if j < 0 {
+			return i, false
+		}
+	}
+	return 0, true
+}
+
+// indentJSON renders a generic JSON value as indented JSON, suitable for a
+// line-by-line diff.
+func indentJSON(v interface{}) string {
+	out, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		panic("Error re-marshaling JSON: " + err.Error())
+	}
+	return string(out)
+}
@@ -0,0 +1,125 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONContains(t *testing.T) {
+	actual := map[string]interface{}{
+		"id":   1,
+		"name": "x",
+		"tags": []string{"a", "b", "c"},
+		"meta": map[string]interface{}{"page": 1, "ts": "now", "a/b": true},
+		"rows": []map[string]interface{}{{"id": 1, "v": "one"}, {"id": 2, "v": "two"}},
+		"none": nil,
+	}
+	tests := []struct {
+		name     string
+		expected string
+		opts     []interface{}
+		want     string
+	}{
+		{
+			name:     "empty object",
+			expected: `{}`,
+		},
+		{
+			name:     "extra keys ignored",
+			expected: `{"name": "x", "meta": {"page": 1}}`,
+		},
+		{
+			name:     "array prefix",
+			expected: `{"tags": ["a", "b"]}`,
+		},
+		{
+			name:     "objects in arrays",
+			expected: `{"rows": [{"id": 1}, {"v": "two"}]}`,
+		},
+		{
+			name:     "null value",
+			expected: `{"none": null}`,
+		},
+		{
+			name:     "differing value",
+			expected: `{"meta": {"page": 2}}`,
+			want:     "JSON does not contain expected value at /meta/page\n-2\n+1",
+		},
+		{
+			name:     "missing key",
+			expected: `{"meta": {"missing": 2}}`,
+			want:     "JSON does not contain expected value at /meta\n-    \"missing\": 2\n+    \"a/b\": true,",
+		},
+		{
+			name:     "key escaped in path",
+			expected: `{"meta": {"a/b": false}}`,
+			want:     "JSON does not contain expected value at /meta/a~1b",
+		},
+		{
+			name:     "null is not missing",
+			expected: `{"absent": null}`,
+			want:     "JSON does not contain expected value at /\n",
+		},
+		{
+			name:     "array too short",
+			expected: `{"tags": ["a", "b", "c", "d"]}`,
+			want:     "JSON does not contain expected value at /tags\n+    \"c\"\n",
+		},
+		{
+			name:     "array order significant",
+			expected: `{"tags": ["b", "a"]}`,
+			want:     "JSON does not contain expected value at /tags/0\n-\"b\"\n+\"a\"",
+		},
+		{
+			name:     "differing element in array of objects",
+			expected: `{"rows": [{"id": 1}, {"id": 3}]}`,
+			want:     "JSON does not contain expected value at /rows/1/id",
+		},
+		{
+			name:     "differing type",
+			expected: `{"tags": {"a": 1}}`,
+			want:     "JSON does not contain expected value at /tags",
+		},
+		{
+			name:     "differing root",
+			expected: `[]`,
+			want:     "JSON does not contain expected value at /\n",
+		},
+		{
+			name:     "unordered arrays",
+			expected: `{"tags": ["c", "a"], "rows": [{"v": "two"}, {"id": 1}]}`,
+			opts:     []interface{}{WithUnorderedArrays()},
+		},
+		{
+			name:     "unordered arrays match distinct elements",
+			expected: `{"tags": ["a", "a"]}`,
+			opts:     []interface{}{WithUnorderedArrays()},
+			want:     "JSON does not contain expected value at /tags/1\n-\"a\"\n+    \"a\",",
+		},
+		{
+			name:     "unordered arrays with no match",
+			expected: `{"rows": [{"id": 2}, {"id": 4}]}`,
+			opts:     []interface{}{WithUnorderedArrays()},
+			want:     "JSON does not contain expected value at /rows/1",
+		},
+		{
+			name:     "invalid expected",
+			expected: `{"id": `,
+			want:     "Error unmarshaling expected JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := JSONContains(m, []byte(tt.expected), actual, tt.opts...)
+			if got != (tt.want == "") {
+				t.Fatalf("JSONContains returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package assert
+
+import "math"
+
+// WithJSONNumberTolerance causes DeepEqualJSON and MarshalsToJSON to consider
+// two JSON numbers equal when their absolute difference is at most epsilon,
+// so that floating point serialization noise does not fail the comparison.
+// Numbers which are equal within the tolerance do not appear in the diff.
+func WithJSONNumberTolerance(epsilon float64) Option {
+	return func(o *options) {
+		o.jsonNumberTolerance = epsilon
+	}
+}
+
+// alignJSONNumbers returns a copy of actual in which every number within
+// epsilon of the number at the same position in expected is replaced by the
+// expected number. Both values are expected to be generic JSON values, as
+// produced by json.Unmarshal.
+func alignJSONNumbers(expected, actual interface{}, epsilon float64) interface{} {
+	switch a := actual.(type) {
+	case float64:
+		if e, ok := expected.(float64); ok && math.Abs(e-a) <= epsilon {
+			return e
+		}
+	case map[string]interface{}:
+		e, _ := expected.(map[string]interface{})
+		aligned := make(map[string]interface{}, len(a))
+		for key, value := range a {
+			aligned[key] = alignJSONNumbers(e[key], value, epsilon)
+		}
+		return aligned
+	case []interface{}:
+		e, _ := expected.([]interface{})
+		aligned := make([]interface{}, len(a))
+		for i, value := range a {
+			var ev interface{}
+			if i < len(e) {
+				ev = e[i]
+			}
+			aligned[i] = alignJSONNumbers(ev, value, epsilon)
+		}
+		return aligned
+	}
+	return actual
+}
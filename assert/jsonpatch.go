@@ -0,0 +1,155 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// patchOp is a single RFC 6902 JSON Patch operation.
+type patchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+	// from is the value replaced or removed by the operation.
+	from interface{}
+}
+
+// WithJSONPatch causes DeepEqualJSON and MarshalsToJSON to report, on
+// failure, the RFC 6902 JSON Patch which would transform expected into actual,
+// in addition to the usual diff.
+func WithJSONPatch() Option {
+	return func(o *options) {
+		o.jsonPatch = true
+	}
+}
+
+// jsonPatch returns the RFC 6902 JSON Patch which transforms expected into
+// actual, rendered with one operation per line. Both values are expected to be
+// generic JSON values, as produced by json.Unmarshal.
+func jsonPatch(expected, actual interface{}) string {
+	ops := patchOps(nil, "", expected, actual)
+	if len(ops) == 0 {
+		return "[]"
+	}
+	lines := make([]string, len(ops))
+	for i, op := range ops {
+		line, err := json.Marshal(op)
+		if err != nil {
+			panic("Error producing JSON patch: " + err.Error())
+		}
+		lines[i] = "    " + string(line)
+	}
+	return "[\n" + strings.Join(lines, ",\n") + "\n]"
+}
+
+func patchOps(ops []patchOp, path string, expected, actual interface{}) []patchOp {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(e)+len(a))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := e[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := path + "/" + escapePointer(key)
+			ev, inE := e[key]
+			av, inA := a[key]
+			switch {
+			case !inA:
+				ops = append(ops, patchOp{Op: "remove", Path: keyPath, from: ev})
+			case !inE:
+				ops = append(ops, newPatchOp("add", keyPath, av))
+			default:
+				ops = patchOps(ops, keyPath, ev, av)
+			}
+		}
+		return ops
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		common := minInt(len(e), len(a))
+		for i := 0; i < common; i++ {
+			ops = patchOps(ops, fmt.Sprintf("%s/%d", path, i), e[i], a[i])
+		}
+		for i := common; i < len(a); i++ {
+			ops = append(ops, newPatchOp("add", fmt.Sprintf("%s/%d", path, i), a[i]))
+		}
+		// Remove from the end, so that earlier indexes remain valid.
+		for i := len(e) - 1; i >= common; i-- {
+			ops = append(ops, patchOp{Op: "remove", Path: fmt.Sprintf("%s/%d", path, i), from: e[i]})
+		}
+		return ops
+	}
+	if reflect.DeepEqual(expected, actual) {
+		return ops
+	}
+	op := newPatchOp("replace", path, actual)
+	op.from = expected
+	return append(ops, op)
+}
+
+func newPatchOp(op, path string, value interface{}) patchOp {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		panic("Error producing JSON patch: " + err.Error())
+	}
+	return patchOp{Op: op, Path: path, Value: raw}
+}
+
+// escapePointer escapes a JSON Pointer reference token, per RFC 6901.
+func escapePointer(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// jsonSummary returns a plain-English summary of the changes which transform
+// expected into actual, with a count of each kind of change followed by one
+// line per change. Both values are expected to be generic JSON values, as
+// produced by json.Unmarshal.
+func jsonSummary(expected, actual interface{}) string {
+	ops := patchOps(nil, "", expected, actual)
+	counts := make(map[string]int)
+	lines := make([]string, len(ops))
+	for i, op := range ops {
+		counts[op.Op]++
+		path := op.Path
+		if path == "" {
+			path = "/"
+		}
+		switch op.Op {
+		case "add":
+			lines[i] = fmt.Sprintf("+ added %s with %s", path, op.Value)
+		case "remove":
+			lines[i] = fmt.Sprintf("- removed %s", path)
+		case "replace":
+			lines[i] = fmt.Sprintf("~ changed %s from %s to %s", path, summaryValue(op.from), op.Value)
+		}
+	}
+	fields := "fields"
+	if counts["replace"] == 1 {
+		fields = "field"
+	}
+	return fmt.Sprintf("%d %s changed, %d added, %d removed\n%s",
+		counts["replace"], fields, counts["add"], counts["remove"], strings.Join(lines, "\n"))
+}
+
+func summaryValue(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		panic("Error producing JSON summary: " + err.Error())
+	}
+	return string(raw)
+}
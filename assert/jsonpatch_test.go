@@ -0,0 +1,234 @@
+package assert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		want             []string
+	}{
+		{
+			name:     "no changes",
+			expected: `{"a": [1, {"b": null}]}`,
+			actual:   `{"a": [1, {"b": null}]}`,
+		},
+		{
+			name: "document",
+			expected: `{
+				"name": "widget",
+				"price": 10,
+				"tags": ["a", "b", "c"],
+				"dims": {"w": 1, "h": 2},
+				"old": true
+			}`,
+			actual: `{
+				"name": "gadget",
+				"price": 10,
+				"tags": ["a", "x"],
+				"dims": {"w": 1, "d": 3},
+				"new": [1]
+			}`,
+			want: []string{
+				`{"op":"add","path":"/dims/d","value":3}`,
+				`{"op":"remove","path":"/dims/h"}`,
+				`{"op":"replace","path":"/name","value":"gadget"}`,
+				`{"op":"add","path":"/new","value":[1]}`,
+				`{"op":"remove","path":"/old"}`,
+				`{"op":"replace","path":"/tags/1","value":"x"}`,
+				`{"op":"remove","path":"/tags/2"}`,
+			},
+		},
+		{
+			name:     "array shrinks, removing from the end",
+			expected: `[1, 2, 3, 4, 5]`,
+			actual:   `[1, 9]`,
+			want: []string{
+				`{"op":"replace","path":"/1","value":9}`,
+				`{"op":"remove","path":"/4"}`,
+				`{"op":"remove","path":"/3"}`,
+				`{"op":"remove","path":"/2"}`,
+			},
+		},
+		{
+			name:     "array grows, adding in order",
+			expected: `{"l": [1]}`,
+			actual:   `{"l": [0, 2, {"k": "v"}]}`,
+			want: []string{
+				`{"op":"replace","path":"/l/0","value":0}`,
+				`{"op":"add","path":"/l/1","value":2}`,
+				`{"op":"add","path":"/l/2","value":{"k":"v"}}`,
+			},
+		},
+		{
+			name:     "escaped keys",
+			expected: `{"a/b": 1, "m~n": {"~/": 1}, "gone~": 1}`,
+			actual:   `{"a/b": 2, "m~n": {"~/": 2}, "new/": 1}`,
+			want: []string{
+				`{"op":"replace","path":"/a~1b","value":2}`,
+				`{"op":"remove","path":"/gone~0"}`,
+				`{"op":"replace","path":"/m~0n/~0~1","value":2}`,
+				`{"op":"add","path":"/new~1","value":1}`,
+			},
+		},
+		{
+			name:     "changed type",
+			expected: `{"a": {"b": 1}}`,
+			actual:   `{"a": [1]}`,
+			want:     []string{`{"op":"replace","path":"/a","value":[1]}`},
+		},
+		{
+			name:     "changed root",
+			expected: `[1]`,
+			actual:   `null`,
+			want:     []string{`{"op":"replace","path":"","value":null}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e, a interface{}
+			if err := json.Unmarshal([]byte(tt.expected), &e); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.actual), &a); err != nil {
+				t.Fatal(err)
+			}
+			want := "[]"
+			if len(tt.want) > 0 {
+				want = "[\n    " + strings.Join(tt.want, ",\n    ") + "\n]"
+			}
+			if got := jsonPatch(e, a); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestJSONSummary(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		want             string
+	}{
+		{
+			name:     "no changes",
+			expected: `{"a": 1}`,
+			actual:   `{"a": 1}`,
+			want:     "0 fields changed, 0 added, 0 removed\n",
+		},
+		{
+			name:     "one change",
+			expected: `{"a": 1}`,
+			actual:   `{"a": 2}`,
+			want:     "1 field changed, 0 added, 0 removed\n~ changed /a from 1 to 2",
+		},
+		{
+			name:     "known changes",
+			expected: `{"a": {"b": 1, "x": 2}, "c": 1, "d": "s", "e": true, "list": [1, 2]}`,
+			actual:   `{"a": {}, "c": 2, "d": "t", "e": false, "new": 5, "list": [1, 2]}`,
+			want: "3 fields changed, 1 added, 2 removed\n" +
+				"- removed /a/b\n" +
+				"- removed /a/x\n" +
+				"~ changed /c from 1 to 2\n" +
+				"~ changed /d from \"s\" to \"t\"\n" +
+				"~ changed /e from true to false\n" +
+				"+ added /new with 5",
+		},
+		{
+			name:     "array elements",
+			expected: `[1, 2, 3, 4]`,
+			actual:   `[1, 5]`,
+			want: "1 field changed, 0 added, 2 removed\n" +
+				"~ changed /1 from 2 to 5\n" +
+				"- removed /3\n" +
+				"- removed /2",
+		},
+		{
+			name:     "added array elements",
+			expected: `{"l": []}`,
+			actual:   `{"l": [{"k": null}, "x"]}`,
+			want: "0 fields changed, 2 added, 0 removed\n" +
+				"+ added /l/0 with {\"k\":null}\n" +
+				"+ added /l/1 with \"x\"",
+		},
+		{
+			name:     "changed type",
+			expected: `{"a": {"b": 1}}`,
+			actual:   `{"a": [1]}`,
+			want:     "1 field changed, 0 added, 0 removed\n~ changed /a from {\"b\":1} to [1]",
+		},
+		{
+			name:     "changed root",
+			expected: `1`,
+			actual:   `"1"`,
+			want:     "1 field changed, 0 added, 0 removed\n~ changed / from 1 to \"1\"",
+		},
+		{
+			name:     "escaped keys",
+			expected: `{"a/b": 1, "c~d": 1}`,
+			actual:   `{}`,
+			want:     "0 fields changed, 0 added, 2 removed\n- removed /a~1b\n- removed /c~0d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e, a interface{}
+			if err := json.Unmarshal([]byte(tt.expected), &e); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.actual), &a); err != nil {
+				t.Fatal(err)
+			}
+			if got := jsonSummary(e, a); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeepEqualJSONSummary(t *testing.T) {
+	expected := map[string]interface{}{"a": map[string]interface{}{"b": 1, "x": 2}, "c": 1, "list": []int{1, 2}}
+	tests := []struct {
+		name   string
+		actual interface{}
+		want   string
+	}{
+		{
+			name:   "equal",
+			actual: map[string]interface{}{"list": []int{1, 2}, "c": 1, "a": map[string]interface{}{"x": 2, "b": 1}},
+		},
+		{
+			name:   "summary before diff",
+			actual: map[string]interface{}{"a": map[string]interface{}{"x": 2}, "c": 2, "list": []int{1, 2}, "new": "v"},
+			want: "JSON representations differ\n" +
+				"1 field changed, 1 added, 1 removed\n" +
+				"- removed /a/b\n" +
+				"~ changed /c from 1 to 2\n" +
+				"+ added /new with \"v\"\n" +
+				"Diff:\n" +
+				"-        \"b\": 1,\n" +
+				"+    \"c\": 2,",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualJSONSummary(m, expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualJSONSummary returned %t:\n%s", got, m.output())
+			}
+			out := m.output()
+			for _, line := range strings.Split(tt.want, "\n") {
+				i := strings.Index(out, line)
+				if i < 0 {
+					t.Fatalf("failure does not contain %q, in order:\n%s", line, m.output())
+				}
+				out = out[i+len(line):]
+			}
+		})
+	}
+}
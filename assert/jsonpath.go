@@ -0,0 +1,346 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JSONPathEqual asserts that evaluating the JSONPath expression path against
+// doc yields expected. doc and expected may each be a []byte or
+// json.RawMessage containing JSON, or any other value, which is first
+// marshaled to JSON. The supported syntax comprises the root ($), child
+// members (.name or ['name']), array indexes ([0], [-1]), slices ([1:3]),
+// wildcards (.* or [*]) and recursive descent (..name). When path contains a
+// wildcard, slice or recursive descent, the result is the array of all
+// matches; otherwise it is the single matched value. On failure, a diff of the
+// matched subtree is shown.
+func JSONPathEqual(t TestingT, doc interface{}, path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	d, err := jsonValue(doc)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid JSON document: %s", err), msgAndArgs...)
+	}
+	e, err := jsonValue(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid expected JSON: %s", err), msgAndArgs...)
+	}
+	segments, definite, err := parseJSONPath(path)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid JSONPath %s: %s", path, err), msgAndArgs...)
+	}
+	matches := []interface{}{d}
+	for _, seg := range segments {
+		matches = seg.apply(matches)
+	}
+	var actual interface{} = matches
+	if definite {
+		if len(matches) == 0 {
+			return Fail(t, fmt.Sprintf("JSONPath %s matched nothing", path), msgAndArgs...)
+		}
+		actual = matches[0]
+	}
+	if reflect.DeepEqual(e, actual) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, fmt.Sprintf("JSONPath %s: values differ", path), indentJSON(e), indentJSON(actual), o, msgAndArgs...)
+}
+
+// JSONPathEqual asserts that evaluating the JSONPath expression path against
+// doc yields expected. doc and expected may each be a []byte or
+// json.RawMessage containing JSON, or any other value, which is first
+// marshaled to JSON. The supported syntax comprises the root ($), child
+// members (.name or ['name']), array indexes ([0], [-1]), slices ([1:3]),
+// wildcards (.* or [*]) and recursive descent (..name). When path contains a
+// wildcard, slice or recursive descent, the result is the array of all
+// matches; otherwise it is the single matched value. On failure, a diff of the
+// matched subtree is shown.
+func (a *Assertions) JSONPathEqual(doc interface{}, path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONPathEqual(a.t, doc, path, expected, a.withOptions(msgAndArgs)...)
+}
+
+// jsonPathSegment is a single step of a JSONPath expression.
+type jsonPathSegment struct {
+	// recursive selects from the current values and all of their
+	// descendants.
+	recursive bool
+	wildcard  bool
+	name      string
+	isIndex   bool
+	index     int
+	isSlice   bool
+	// start and end bound a slice; nil means unbounded.
+	start, end *int
+}
+
+// parseJSONPath parses path into segments. definite is true if path selects
+// at most one value.
+func parseJSONPath(path string) (segments []jsonPathSegment, definite bool, err error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, false, errors.New("must begin with $")
+	}
+	definite = true
+	rest := path[1:]
+	for rest != "" {
+		var seg jsonPathSegment
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			seg.recursive = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				break
+			}
+			fallthrough
+		case strings.HasPrefix(rest, "."):
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, false, errors.New("empty member name")
+			}
+			seg.name, rest = rest[:end], rest[end:]
+			seg.wildcard = seg.name == "*"
+			if seg.recursive || seg.wildcard {
+				definite = false
+			}
+			segments = append(segments, seg)
+			continue
+		case !strings.HasPrefix(rest, "["):
+			return nil, false, errors.Errorf("unexpected %q", rest)
+		}
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return nil, false, errors.New("unterminated [")
+		}
+		sel := strings.TrimSpace(rest[1:end])
+		rest = rest[end+1:]
+		switch {
+		case sel == "*":
+			seg.wildcard = true
+		case len(sel) >= 2 && (sel[0] == '\'' || sel[0] == '"') && sel[len(sel)-1] == sel[0]:
+			seg.name = sel[1 : len(sel)-1]
+		case strings.Contains(sel, ":"):
+			seg.isSlice = true
+			bounds := strings.SplitN(sel, ":", 2)
+			for i, bound := range bounds {
+				if bound = strings.TrimSpace(bound); bound == "" {
+					continue
+				}
+				n, err := strconv.Atoi(bound)
+				if err != nil {
+					return nil, false, errors.Errorf("invalid slice %q", sel)
+				}
+				if i == 0 {
+					seg.start = &n
+				} else {
+					seg.end = &n
+				}
+			}
+		default:
+			n, err := strconv.Atoi(sel)
+			if err != nil {
+				return nil, false, errors.Errorf("unsupported selector %q", sel)
+			}
+			seg.isIndex, seg.index = true, n
+		}
+		if seg.recursive || seg.wildcard || seg.isSlice {
+			definite = false
+		}
+		segments = append(segments, seg)
+	}
+	return segments, definite, nil
+}
+
+// apply returns the values selected by seg from each of values.
+func (seg jsonPathSegment) apply(values []interface{}) []interface{} {
+	if seg.recursive {
+		var all []interface{}
+		for _, v := range values {
+			all = appendDescendants(all, v)
+		}
+		values = all
+	}
+	var result []interface{}
+	for _, v := range values {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, key := range seg.keys(v) {
+				result = append(result, v[key])
+			}
+		case []interface{}:
+			for _, i := range seg.indexes(len(v)) {
+				result = append(result, v[i])
+			}
+		}
+	}
+	return result
+}
+
+// keys returns the keys of m selected by seg, in sorted order.
+func (seg jsonPathSegment) keys(m map[string]interface{}) []string {
+	switch {
+	case seg.wildcard:
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	case !seg.isIndex && !seg.isSlice:
+		if _, ok := m[seg.name]; ok {
+			return []string{seg.name}
+		}
+	}
+	return nil
+}
+
+// indexes returns the indexes selected by seg from an array of the given
+// length, in ascending order.
+func (seg jsonPathSegment) indexes(length int) []int {
+	start, end := 0, 0
+	switch {
+	case seg.wildcard:
+		end = length
+	case seg.isIndex:
+		i := seg.index
+		if i < 0 {
+			i += length
+		}
+		if i >= 0 && i < length {
+			start, end = i, i+1
+		}
+	case seg.isSlice:
+		end = length
+		if seg.start != nil {
+			start = sliceBound(*seg.start, length)
+		}
+		if seg.end != nil {
+			end = sliceBound(*seg.end, length)
+		}
+	}
+	var indexes []int
+	for i := start; i < end; i++ {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func sliceBound(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	return maxInt(0, minInt(i, length))
+}
+
+// appendDescendants appends v and all of its descendants, in document order,
+// to values.
+func appendDescendants(values []interface{}, v interface{}) []interface{} {
+	values = append(values, v)
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			values = appendDescendants(values, v[key])
+		}
+	case []interface{}:
+		for _, child := range v {
+			values = appendDescendants(values, child)
+		}
+	}
+	return values
+}
+
+// WithIgnoredJSONPaths causes DeepEqualJSON to ignore the values selected by
+// each of the given JSONPath expressions, such as "$.id" or
+// "$.items[*].created_at", in both the expected and actual JSON. Ignored
+// values are omitted from the diff. See JSONPathEqual for the supported
+// syntax.
+func WithIgnoredJSONPaths(paths ...string) Option {
+	return func(o *options) {
+		o.ignoredJSONPaths = append(o.ignoredJSONPaths, paths...)
+	}
+}
+
+// removeJSONPaths removes the values selected by each of paths from v,
+// returning the result.
+func removeJSONPaths(v interface{}, paths []string) (interface{}, error) {
+	for _, path := range paths {
+		segments, _, err := parseJSONPath(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid JSONPath %s", path)
+		}
+		if len(segments) == 0 {
+			return nil, errors.Errorf("invalid JSONPath %s: cannot ignore the root", path)
+		}
+		v = removeJSONPath(v, segments)
+	}
+	return v, nil
+}
+
+// removeJSONPath removes the values selected by segments from v, returning
+// the result. Objects are modified in place.
+func removeJSONPath(v interface{}, segments []jsonPathSegment) interface{} {
+	seg := segments[0]
+	if seg.recursive {
+		seg.recursive = false
+		v = removeJSONPath(v, append([]jsonPathSegment{seg}, segments[1:]...))
+		switch c := v.(type) {
+		case map[string]interface{}:
+			for key, child := range c {
+				c[key] = removeJSONPath(child, segments)
+			}
+		case []interface{}:
+			for i, child := range c {
+				c[i] = removeJSONPath(child, segments)
+			}
+		}
+		return v
+	}
+	last := len(segments) == 1
+	switch c := v.(type) {
+	case map[string]interface{}:
+		for _, key := range seg.keys(c) {
+			if last {
+				delete(c, key)
+				continue
+			}
+			c[key] = removeJSONPath(c[key], segments[1:])
+		}
+	case []interface{}:
+		indexes := seg.indexes(len(c))
+		if !last {
+			for _, i := range indexes {
+				c[i] = removeJSONPath(c[i], segments[1:])
+			}
+			return c
+		}
+		remove := make(map[int]bool, len(indexes))
+		for _, i := range indexes {
+			remove[i] = true
+		}
+		kept := make([]interface{}, 0, len(c)-len(indexes))
+		for i, child := range c {
+			if !remove[i] {
+				kept = append(kept, child)
+			}
+		}
+		return kept
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// MatchesJSONSchema asserts that actual is valid according to the JSON Schema
+// document schema. schema and actual may each be a []byte or json.RawMessage
+// containing JSON, an io.Reader from which JSON is read, or any other value,
+// which is first marshaled to JSON. On failure, each violation is reported
+// along with the JSON pointer to the offending value.
+func MatchesJSONSchema(t TestingT, schema, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	s, err := jsonSchemaValue(schema)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid JSON Schema: %s", err), msgAndArgs...)
+	}
+	value, err := jsonSchemaValue(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid actual JSON: %s", err), msgAndArgs...)
+	}
+	const url = "schema.json"
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, s); err != nil {
+		return Fail(t, fmt.Sprintf("Invalid JSON Schema: %s", err), msgAndArgs...)
+	}
+	compiled, err := compiler.Compile(url)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid JSON Schema: %s", err), msgAndArgs...)
+	}
+	err = compiled.Validate(value)
+	if err == nil {
+		return true
+	}
+	ve, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return Fail(t, fmt.Sprintf("JSON Schema validation failed: %s", err), msgAndArgs...)
+	}
+	p := message.NewPrinter(language.English)
+	return Fail(t, fmt.Sprintf("Value does not match JSON Schema:\n%s",
+		strings.Join(jsonSchemaViolations(ve, p), "\n")), msgAndArgs...)
+}
+
+// MatchesJSONSchema asserts that actual is valid according to the JSON Schema
+// document schema. schema and actual may each be a []byte or json.RawMessage
+// containing JSON, an io.Reader from which JSON is read, or any other value,
+// which is first marshaled to JSON. On failure, each violation is reported
+// along with the JSON pointer to the offending value.
+func (a *Assertions) MatchesJSONSchema(schema, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MatchesJSONSchema(a.t, schema, actual, a.withOptions(msgAndArgs)...)
+}
+
+// jsonSchemaValue returns i as a generic JSON value, decoded as expected by
+// the jsonschema package, with numbers as json.Number.
+func jsonSchemaValue(i interface{}) (interface{}, error) {
+	var data []byte
+	switch v := i.(type) {
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	case io.Reader:
+		var err error
+		if data, err = ioutil.ReadAll(v); err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		if data, err = json.Marshal(i); err != nil {
+			return nil, err
+		}
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// jsonSchemaViolations flattens a validation error into its leaf violations,
+// each prefixed by the JSON pointer to the offending value.
+func jsonSchemaViolations(ve *jsonschema.ValidationError, p *message.Printer) []string {
+	if len(ve.Causes) == 0 {
+		tokens := make([]string, len(ve.InstanceLocation))
+		for i, token := range ve.InstanceLocation {
+			tokens[i] = escapePointer(token)
+		}
+		return []string{fmt.Sprintf("/%s: %s", strings.Join(tokens, "/"), ve.ErrorKind.LocalizedString(p))}
+	}
+	var violations []string
+	for _, cause := range ve.Causes {
+		violations = append(violations, jsonSchemaViolations(cause, p)...)
+	}
+	return violations
+}
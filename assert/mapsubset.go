@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MapSubset asserts that every key of the expected map is present in the
+// actual map, with a deeply equal value. The actual map may contain other
+// keys. On failure, the missing and differing keys are reported, along with a
+// diff of expected against the matching subset of actual. The comparison
+// options accepted by DeepEqual, such as WithFloatTolerance, also apply to
+// the values.
+func MapSubset(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, act := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if exp.Kind() != reflect.Map {
+		return Fail(t, fmt.Sprintf("%T is not a map", expected), msgAndArgs...)
+	}
+	if act.Kind() != reflect.Map {
+		return Fail(t, fmt.Sprintf("%T is not a map", actual), msgAndArgs...)
+	}
+	if exp.Type().Key() != act.Type().Key() {
+		return Fail(t, fmt.Sprintf("Map key types differ: %s and %s", exp.Type().Key(), act.Type().Key()), msgAndArgs...)
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	subset := reflect.MakeMapWithSize(act.Type(), exp.Len())
+	var missing, differing []string
+	for _, key := range exp.MapKeys() {
+		value := act.MapIndex(key)
+		if !value.IsValid() {
+			missing = append(missing, fmt.Sprintf("%#v", key.Interface()))
+			continue
+		}
+		subset.SetMapIndex(key, value)
+		if len(differences(exp.MapIndex(key).Interface(), value.Interface(), &o.compare)) > 0 {
+			differing = append(differing, fmt.Sprintf("%#v", key.Interface()))
+		}
+	}
+	if len(missing) == 0 && len(differing) == 0 {
+		return true
+	}
+	sort.Strings(missing)
+	sort.Strings(differing)
+	msg := "Map does not contain the expected subset"
+	if len(missing) > 0 {
+		msg += fmt.Sprintf("\nmissing keys  : %s", strings.Join(missing, ", "))
+	}
+	if len(differing) > 0 {
+		msg += fmt.Sprintf("\ndiffering keys: %s", strings.Join(differing, ", "))
+	}
+	return failInterfaceDiff(t, msg, expected, subset.Interface(), o, msgAndArgs...)
+}
+
+// MapSubset asserts that every key of the expected map is present in the
+// actual map, with a deeply equal value. The actual map may contain other
+// keys. On failure, the missing and differing keys are reported, along with a
+// diff of expected against the matching subset of actual. The comparison
+// options accepted by DeepEqual, such as WithFloatTolerance, also apply to
+// the values.
+func (a *Assertions) MapSubset(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MapSubset(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
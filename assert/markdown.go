@@ -0,0 +1,46 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+)
+
+// MarkdownEqual asserts that the two Markdown documents render to equivalent
+// HTML, as determined by HTMLEqual, so that insignificant differences in
+// Markdown formatting, such as the choice of emphasis or list markers, are
+// ignored.
+func MarkdownEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expHTML, err := renderMarkdown(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error rendering expected Markdown: %s", err), msgAndArgs...)
+	}
+	actHTML, err := renderMarkdown(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error rendering actual Markdown: %s", err), msgAndArgs...)
+	}
+	return htmlEqual(t, expHTML, actHTML, false, msgAndArgs...)
+}
+
+// MarkdownEqual asserts that the two Markdown documents render to equivalent
+// HTML, as determined by HTMLEqual, so that insignificant differences in
+// Markdown formatting, such as the choice of emphasis or list markers, are
+// ignored.
+func (a *Assertions) MarkdownEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MarkdownEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+func renderMarkdown(md string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := goldmark.Convert([]byte(md), buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DeepEqualExcept asserts that two objects are deeply equal, ignoring the
+// struct fields named in ignoreFields. Fields are matched by their Go field
+// name at any depth, including within nested structs, pointers, slices, arrays
+// and maps, so that passing "CreatedAt" ignores every CreatedAt field in the
+// tree. Only exported fields, and values reachable through them, are masked.
+// Neither expected nor actual is modified.
+func DeepEqualExcept(t TestingT, expected, actual interface{}, ignoreFields []string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualWithOptions(t, expected, actual, Options{IgnoreFields: ignoreFields}, msgAndArgs...)
+}
+
+// DeepEqualExcept asserts that two objects are deeply equal, ignoring the
+// struct fields named in ignoreFields. Fields are matched by their Go field
+// name at any depth, including within nested structs, pointers, slices, arrays
+// and maps, so that passing "CreatedAt" ignores every CreatedAt field in the
+// tree. Only exported fields, and values reachable through them, are masked.
+// Neither expected nor actual is modified.
+func (a *Assertions) DeepEqualExcept(expected, actual interface{}, ignoreFields []string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualExcept(a.t, expected, actual, ignoreFields, a.withOptions(msgAndArgs)...)
+}
+
+// maskFields returns a copy of i, in which every exported struct field named
+// in opts.IgnoreFields, and every value at a path matching one of
+// opts.IgnorePaths, is set to its zero value.
+func maskFields(i interface{}, opts *Options) interface{} {
+	if i == nil {
+		return i
+	}
+	m := &masker{
+		fields: make(map[string]bool, len(opts.IgnoreFields)),
+		copies: make(map[ref]reflect.Value),
+	}
+	for _, field := range opts.IgnoreFields {
+		m.fields[field] = true
+	}
+	for _, path := range opts.IgnorePaths {
+		m.paths = append(m.paths, splitPath(path))
+	}
+	return m.mask(reflect.ValueOf(i), "").Interface()
+}
+
+type masker struct {
+	fields map[string]bool
+	paths  [][]string
+	// copies maps each original pointer, map, or slice to its masked copy, so
+	// that shared and cyclic references are preserved.
+	copies map[ref]reflect.Value
+}
+
+// ignored reports whether the value at path is to be masked.
+func (m *masker) ignored(path string) bool {
+	if len(m.paths) == 0 {
+		return false
+	}
+	segments := splitPath(path)
+	for _, pattern := range m.paths {
+		if pathMatches(pattern, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathMatches reports whether the path segments match pattern, in which a
+// segment of [*] matches any index or key.
+func pathMatches(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != segments[i] && !(p == "[*]" && strings.HasPrefix(segments[i], "[")) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *masker) mask(v reflect.Value, path string) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := m.copies[refOf(v)]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		m.copies[refOf(v)] = c
+		c.Elem().Set(m.mask(v.Elem(), path))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(m.mask(v.Elem(), path))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			fpath := fieldPath(path, field.Name)
+			if m.fields[field.Name] || m.ignored(fpath) {
+				c.Field(i).Set(reflect.Zero(field.Type))
+				continue
+			}
+			c.Field(i).Set(m.mask(v.Field(i), fpath))
+		}
+		return c
+	case reflect.Slice, reflect.Array:
+		var c reflect.Value
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return v
+			}
+			if c, ok := m.copies[refOf(v)]; ok {
+				return c
+			}
+			c = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			m.copies[refOf(v)] = c
+		} else {
+			c = reflect.New(v.Type()).Elem()
+		}
+		for i := 0; i < v.Len(); i++ {
+			ipath := fmt.Sprintf("%s[%d]", path, i)
+			if m.ignored(ipath) {
+				continue
+			}
+			c.Index(i).Set(m.mask(v.Index(i), ipath))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := m.copies[refOf(v)]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		m.copies[refOf(v)] = c
+		for _, key := range v.MapKeys() {
+			kpath := keyPath(path, key)
+			if m.ignored(kpath) {
+				c.SetMapIndex(key, reflect.Zero(v.Type().Elem()))
+				continue
+			}
+			c.SetMapIndex(key, m.mask(v.MapIndex(key), kpath))
+		}
+		return c
+	}
+	return v
+}
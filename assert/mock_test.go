@@ -0,0 +1,26 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mockT is a TestingT which records the failures reported to it.
+type mockT struct {
+	failed bool
+	msgs   []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.failed = true
+	m.msgs = append(m.msgs, fmt.Sprintf(format, args...))
+}
+
+func (m *mockT) FailNow() {
+	m.failed = true
+}
+
+// output returns the failure messages reported to m.
+func (m *mockT) output() string {
+	return strings.Join(m.msgs, "\n")
+}
@@ -0,0 +1,53 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MsgpackEqual asserts that the two MessagePack-encoded byte slices decode to
+// deeply equal values. Numbers are compared regardless of the width with
+// which they were encoded. On failure, a structured diff of the decoded values
+// is shown.
+func MsgpackEqual(t TestingT, expected, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	e, err := decodeMsgpack(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error decoding expected MessagePack: %s", err), msgAndArgs...)
+	}
+	a, err := decodeMsgpack(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error decoding actual MessagePack: %s", err), msgAndArgs...)
+	}
+	if reflect.DeepEqual(e, a) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failInterfaceDiff(t, "MessagePack values differ", e, a, o, msgAndArgs...)
+}
+
+// MsgpackEqual asserts that the two MessagePack-encoded byte slices decode to
+// deeply equal values. Numbers are compared regardless of the width with
+// which they were encoded. On failure, a structured diff of the decoded values
+// is shown.
+func (a *Assertions) MsgpackEqual(expected, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MsgpackEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// decodeMsgpack decodes data loosely, so that integers and floats decode to
+// int64, uint64 and float64 regardless of the width chosen by the encoder.
+func decodeMsgpack(data []byte) (interface{}, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.UseLooseInterfaceDecoding(true)
+	var v interface{}
+	err := dec.Decode(&v)
+	return v, err
+}
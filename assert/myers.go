@@ -0,0 +1,94 @@
+package assert
+
+import "github.com/pmezard/go-difflib/difflib"
+
+// myersOpCodes computes a minimal edit script transforming a into b, using
+// the algorithm described in Eugene W. Myers' "An O(ND) Difference Algorithm
+// and Its Variations", and returns it in the form of difflib opcodes.
+func myersOpCodes(a, b []string) []difflib.OpCode {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	// Only the diagonals -d-1 through d+1 of v are read when backtracking from
+	// step d, so only that window is kept, rather than all of v.
+	var trace [][]int
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace backwards to recover the path, recording for each step
+	// whether it was a match ('e'), deletion ('d') or insertion ('i').
+	steps := make([]byte, 0, max)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[d+k] < v[d+k+2]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[d+1+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			steps = append(steps, 'e')
+			x, y = x-1, y-1
+		}
+		if d > 0 {
+			if x == prevX {
+				steps = append(steps, 'i')
+			} else {
+				steps = append(steps, 'd')
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	var codes []difflib.OpCode
+	var i, j int
+	for s := len(steps) - 1; s >= 0; {
+		i1, j1 := i, j
+		if steps[s] == 'e' {
+			for ; s >= 0 && steps[s] == 'e'; s-- {
+				i, j = i+1, j+1
+			}
+			codes = append(codes, difflib.OpCode{Tag: 'e', I1: i1, I2: i, J1: j1, J2: j})
+			continue
+		}
+		for ; s >= 0 && steps[s] != 'e'; s-- {
+			if steps[s] == 'd' {
+				i++
+			} else {
+				j++
+			}
+		}
+		tag := byte('r')
+		switch {
+		case i == i1:
+			tag = 'i'
+		case j == j1:
+			tag = 'd'
+		}
+		codes = append(codes, difflib.OpCode{Tag: tag, I1: i1, I2: i, J1: j1, J2: j})
+	}
+	return codes
+}
@@ -0,0 +1,137 @@
+package assert
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// splitLines splits s after each newline, omitting the empty remainder.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	return lines[:len(lines)-1]
+}
+
+// editCount returns the number of lines deleted or inserted by codes.
+func editCount(codes []difflib.OpCode) int {
+	var n int
+	for _, c := range codes {
+		if c.Tag != 'e' {
+			n += (c.I2 - c.I1) + (c.J2 - c.J1)
+		}
+	}
+	return n
+}
+
+// minimalEdits returns the length of the shortest edit script transforming a
+// into b, computed from the longest common subsequence.
+func minimalEdits(a, b []string) int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] > lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	return len(a) + len(b) - 2*lcs[0][0]
+}
+
+// checkOpCodes verifies that codes are contiguous, and transform a into b.
+func checkOpCodes(t *testing.T, a, b []string, codes []difflib.OpCode) {
+	t.Helper()
+	var i, j int
+	var got []string
+	for _, c := range codes {
+		if c.I1 != i || c.J1 != j {
+			t.Fatalf("opcode %c %d:%d,%d:%d does not follow %d,%d", c.Tag, c.I1, c.I2, c.J1, c.J2, i, j)
+		}
+		if c.Tag == 'e' {
+			for k := c.I1; k < c.I2; k++ {
+				if a[k] != b[c.J1+k-c.I1] {
+					t.Fatalf("opcode e %d:%d,%d:%d matches unequal lines", c.I1, c.I2, c.J1, c.J2)
+				}
+			}
+		}
+		got = append(got, b[c.J1:c.J2]...)
+		i, j = c.I2, c.J2
+	}
+	if i != len(a) || j != len(b) {
+		t.Fatalf("opcodes end at %d,%d, want %d,%d", i, j, len(a), len(b))
+	}
+	if strings.Join(got, "") != strings.Join(b, "") {
+		t.Fatalf("opcodes produce %q, want %q", got, b)
+	}
+}
+
+func TestMyersOpCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{name: "both empty"},
+		{name: "insert all", b: "a\nb\n"},
+		{name: "delete all", a: "a\nb\n"},
+		{name: "equal", a: "a\nb\nc\n", b: "a\nb\nc\n"},
+		{name: "replace", a: "a\nb\nc\n", b: "a\nx\nc\n"},
+		{name: "move", a: "a\nb\nc\nd\n", b: "d\na\nb\nc\n"},
+		{name: "disjoint", a: "a\nb\n", b: "c\nd\ne\n"},
+		{name: "longest match is not minimal", a: "b\nc\na\nb\n", b: "c\nb\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := splitLines(tt.a), splitLines(tt.b)
+			codes := myersOpCodes(a, b)
+			checkOpCodes(t, a, b, codes)
+			if got, want := editCount(codes), minimalEdits(a, b); got != want {
+				t.Errorf("got %d edits, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestMyersOpCodesMinimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	lines := func() []string {
+		s := make([]string, r.Intn(40))
+		for i := range s {
+			s[i] = string(rune('a'+r.Intn(4))) + "\n"
+		}
+		return s
+	}
+	for n := 0; n < 500; n++ {
+		a, b := lines(), lines()
+		codes := myersOpCodes(a, b)
+		checkOpCodes(t, a, b, codes)
+		if got, want := editCount(codes), minimalEdits(a, b); got != want {
+			t.Fatalf("%q to %q: got %d edits, want %d", a, b, got, want)
+		}
+	}
+}
+
+func TestDiffAlgorithmMinimality(t *testing.T) {
+	// difflib anchors on the leading "b" of expected, so the "c" preceding
+	// "b" in actual can no longer be matched.
+	a, b := splitLines("b\nc\na\nb\n"), splitLines("c\nb\n")
+	if got := editCount(opCodes(a, b, Difflib)); got != 4 {
+		t.Errorf("Difflib: got %d edits, want 4", got)
+	}
+	if got := editCount(opCodes(a, b, Myers)); got != 2 {
+		t.Errorf("Myers: got %d edits, want 2", got)
+	}
+
+	want := "--- expected\n+++ actual\n@@ -1,5 +1,3 @@\n-b\n c\n-a\n b\n "
+	if got := diff("b\nc\na\nb\n", "c\nb\n", &options{diffContext: defaultDiffContext, diffAlgorithm: Myers}); got != want {
+		t.Errorf("got diff %q, want %q", got, want)
+	}
+}
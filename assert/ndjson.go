@@ -0,0 +1,108 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxNDJSONDifferences is the greatest number of mismatched records listed in
+// an NDJSONEqual failure message.
+const maxNDJSONDifferences = 10
+
+// ndjsonRecord is a single record of an NDJSON (JSON Lines) document.
+type ndjsonRecord struct {
+	line  int
+	value interface{}
+}
+
+// NDJSONEqual asserts that the two NDJSON (newline-delimited JSON, or JSON
+// Lines) documents contain semantically equal records in the same order. Each
+// non-blank line is parsed as a JSON value, so differences in whitespace or
+// key order within a record are ignored. On failure, the line numbers of the
+// mismatched records are reported, followed by a diff of the records, each
+// rendered as canonical JSON on a single line.
+func NDJSONEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, err := parseNDJSON(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected NDJSON: %s", err), msgAndArgs...)
+	}
+	act, err := parseNDJSON(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing actual NDJSON: %s", err), msgAndArgs...)
+	}
+	var diffs []string
+	for i := 0; i < maxInt(len(exp), len(act)); i++ {
+		switch {
+		case i >= len(act):
+			diffs = append(diffs, fmt.Sprintf("record %d (expected line %d) is missing", i+1, exp[i].line))
+		case i >= len(exp):
+			diffs = append(diffs, fmt.Sprintf("record %d (actual line %d) is unexpected", i+1, act[i].line))
+		case !reflect.DeepEqual(exp[i].value, act[i].value):
+			diffs = append(diffs, fmt.Sprintf("record %d (expected line %d, actual line %d) differs", i+1, exp[i].line, act[i].line))
+		}
+	}
+	if len(diffs) == 0 {
+		return true
+	}
+	msg := "NDJSON records differ"
+	for i, d := range diffs {
+		if i == maxNDJSONDifferences {
+			msg += fmt.Sprintf("\n... and %d more", len(diffs)-i)
+			break
+		}
+		msg += "\n" + d
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, msg, formatNDJSON(exp), formatNDJSON(act), o, msgAndArgs...)
+}
+
+// NDJSONEqual asserts that the two NDJSON (newline-delimited JSON, or JSON
+// Lines) documents contain semantically equal records in the same order. Each
+// non-blank line is parsed as a JSON value, so differences in whitespace or
+// key order within a record are ignored. On failure, the line numbers of the
+// mismatched records are reported, followed by a diff of the records, each
+// rendered as canonical JSON on a single line.
+func (a *Assertions) NDJSONEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NDJSONEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// parseNDJSON parses each non-blank line of doc as a JSON value.
+func parseNDJSON(doc string) ([]ndjsonRecord, error) {
+	var records []ndjsonRecord
+	for i, line := range strings.Split(doc, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+		records = append(records, ndjsonRecord{line: i + 1, value: v})
+	}
+	return records, nil
+}
+
+// formatNDJSON renders each record as compact JSON, with sorted keys, one
+// record per line.
+func formatNDJSON(records []ndjsonRecord) string {
+	var buf strings.Builder
+	for _, r := range records {
+		line, err := json.Marshal(r.value)
+		if err != nil {
+			panic("Error re-marshaling JSON: " + err.Error())
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
@@ -0,0 +1,60 @@
+package assert
+
+// WithNilEqualsEmpty causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to consider nil slices and maps equal to empty ones.
+// DeepEqualJSON and MarshalsToJSON likewise consider a JSON null equal to an
+// empty array or object. Such distinctions rarely survive a round trip
+// through JSON or a database.
+func WithNilEqualsEmpty() Option {
+	return func(o *options) {
+		o.compare = o.compare.merge(Options{NilEqualsEmpty: true})
+	}
+}
+
+// alignJSONNulls returns a copy of actual in which every null at the position
+// of an empty array or object in expected is replaced by that empty value,
+// and every empty array or object at the position of a null in expected is
+// replaced by null. Both values are expected to be generic JSON values, as
+// produced by json.Unmarshal.
+func alignJSONNulls(expected, actual interface{}) interface{} {
+	if actual == nil {
+		switch e := expected.(type) {
+		case []interface{}:
+			if len(e) == 0 {
+				return e
+			}
+		case map[string]interface{}:
+			if len(e) == 0 {
+				return e
+			}
+		}
+		return actual
+	}
+	switch a := actual.(type) {
+	case map[string]interface{}:
+		if len(a) == 0 && expected == nil {
+			return nil
+		}
+		e, _ := expected.(map[string]interface{})
+		aligned := make(map[string]interface{}, len(a))
+		for key, value := range a {
+			aligned[key] = alignJSONNulls(e[key], value)
+		}
+		return aligned
+	case []interface{}:
+		if len(a) == 0 && expected == nil {
+			return nil
+		}
+		e, _ := expected.([]interface{})
+		aligned := make([]interface{}, len(a))
+		for i, value := range a {
+			var ev interface{}
+			if i < len(e) {
+				ev = e[i]
+			}
+			aligned[i] = alignJSONNulls(ev, value)
+		}
+		return aligned
+	}
+	return actual
+}
@@ -0,0 +1,159 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// OpenAPIResponseValid asserts that actual is valid according to the response
+// schema defined in the OpenAPI spec found at specPath, for the operation
+// identified by operationID and the given HTTP status code. If the operation
+// defines no response for statusCode, the default response is used. The
+// schema is that of its application/json content or, failing that, of another
+// JSON media type, such as application/problem+json. actual may be a []byte
+// or json.RawMessage containing JSON, or any value which will be marshaled to
+// JSON.
+func OpenAPIResponseValid(t TestingT, specPath, operationID string, statusCode int, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	schema, err := openAPIResponseSchema(specPath, operationID, statusCode)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid OpenAPI spec: %s", err), msgAndArgs...)
+	}
+	value, err := jsonValue(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid actual JSON: %s", err), msgAndArgs...)
+	}
+	err = schema.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Response does not match schema for %s (%d):\n%s",
+		operationID, statusCode, strings.Join(schemaViolations(err), "\n")), msgAndArgs...)
+}
+
+// OpenAPIResponseValid asserts that actual is valid according to the response
+// schema defined in the OpenAPI spec found at specPath, for the operation
+// identified by operationID and the given HTTP status code. If the operation
+// defines no response for statusCode, the default response is used. The
+// schema is that of its application/json content or, failing that, of another
+// JSON media type, such as application/problem+json. actual may be a []byte
+// or json.RawMessage containing JSON, or any value which will be marshaled to
+// JSON.
+func (a *Assertions) OpenAPIResponseValid(specPath, operationID string, statusCode int, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return OpenAPIResponseValid(a.t, specPath, operationID, statusCode, actual, a.withOptions(msgAndArgs)...)
+}
+
+func openAPIResponseSchema(specPath, operationID string, statusCode int) (*openapi3.Schema, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	var op *openapi3.Operation
+	for _, item := range doc.Paths.Map() {
+		for _, o := range item.Operations() {
+			if o.OperationID == operationID {
+				op = o
+			}
+		}
+	}
+	if op == nil {
+		return nil, errors.Errorf("operation %q not found", operationID)
+	}
+	if op.Responses == nil {
+		return nil, errors.Errorf("operation %q defines no responses", operationID)
+	}
+	resp := op.Responses.Status(statusCode)
+	if resp == nil {
+		resp = op.Responses.Default()
+	}
+	if resp == nil || resp.Value == nil {
+		return nil, errors.Errorf("operation %q defines no response for status %d", operationID, statusCode)
+	}
+	media := jsonMediaType(resp.Value.Content)
+	if media == nil || media.Schema == nil || media.Schema.Value == nil {
+		return nil, errors.Errorf("operation %q defines no JSON schema for status %d", operationID, statusCode)
+	}
+	return media.Schema.Value, nil
+}
+
+// jsonMediaType returns the JSON media type defined by content: that for
+// application/json, if defined, or else the first, in sorted order, which
+// isJSONMediaType accepts, such as application/problem+json, or else any
+// wildcard, such as application/*, which covers application/json. It returns
+// nil if there is none.
+func jsonMediaType(content openapi3.Content) *openapi3.MediaType {
+	if media, ok := content["application/json"]; ok {
+		return media
+	}
+	types := make([]string, 0, len(content))
+	for typ := range content {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		if isJSONMediaType(typ) {
+			return content[typ]
+		}
+	}
+	return content.Get("application/json")
+}
+
+// isJSONMediaType reports whether contentType is application/json, or a
+// media type with the +json structured syntax suffix.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// schemaViolations flattens a schema validation error into a list of
+// violations, each prefixed by the JSON pointer to the offending value.
+func schemaViolations(err error) []string {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var violations []string
+		for _, err := range e {
+			violations = append(violations, schemaViolations(err)...)
+		}
+		return violations
+	case *openapi3.SchemaError:
+		return []string{fmt.Sprintf("/%s: %s", strings.Join(e.JSONPointer(), "/"), e.Reason)}
+	}
+	return []string{err.Error()}
+}
+
+// jsonValue returns i as a generic JSON value, as produced by json.Unmarshal.
+// A []byte or json.RawMessage is taken to contain JSON; any other value is
+// first marshaled.
+func jsonValue(i interface{}) (interface{}, error) {
+	var data []byte
+	switch v := i.(type) {
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(i); err != nil {
+			return nil, err
+		}
+	}
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
@@ -0,0 +1,116 @@
+package assert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPIResponseValid(t *testing.T) {
+	const spec = "testdata/openapi.yaml"
+	tests := []struct {
+		name        string
+		operationID string
+		statusCode  int
+		actual      interface{}
+		want        string
+	}{
+		{
+			name:        "valid value",
+			operationID: "getUser",
+			statusCode:  200,
+			actual:      map[string]interface{}{"id": 1, "name": "Bob"},
+		},
+		{
+			name:        "valid JSON",
+			operationID: "getUser",
+			statusCode:  200,
+			actual:      json.RawMessage(`{"id": 1, "name": "Bob"}`),
+		},
+		{
+			name:        "wrong type",
+			operationID: "getUser",
+			statusCode:  200,
+			actual:      []byte(`{"id": "1", "name": "Bob"}`),
+			want:        "/id: value must be an integer",
+		},
+		{
+			name:        "missing property",
+			operationID: "getUser",
+			statusCode:  200,
+			actual:      []byte(`{"id": 1}`),
+			want:        `property "name" is missing`,
+		},
+		{
+			name:        "structured syntax suffix",
+			operationID: "getUser",
+			statusCode:  404,
+			actual:      []byte(`{"title": "Not Found"}`),
+		},
+		{
+			name:        "structured syntax suffix violation",
+			operationID: "getUser",
+			statusCode:  404,
+			actual:      []byte(`{}`),
+			want:        `property "title" is missing`,
+		},
+		{
+			name:        "default response with parameters",
+			operationID: "getUser",
+			statusCode:  500,
+			actual:      []byte(`{"error": 1}`),
+			want:        "/error: value must be a string",
+		},
+		{
+			name:        "no JSON media type",
+			operationID: "getAvatar",
+			statusCode:  200,
+			actual:      []byte(`"x"`),
+			want:        `operation "getAvatar" defines no JSON schema for status 200`,
+		},
+		{
+			name:        "unknown operation",
+			operationID: "deleteUser",
+			statusCode:  200,
+			actual:      []byte(`{}`),
+			want:        `operation "deleteUser" not found`,
+		},
+		{
+			name:        "invalid JSON",
+			operationID: "getUser",
+			statusCode:  200,
+			actual:      []byte(`{`),
+			want:        "Invalid actual JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := OpenAPIResponseValid(m, spec, tt.operationID, tt.statusCode, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("OpenAPIResponseValid returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
+
+func TestIsJSONMediaType(t *testing.T) {
+	tests := map[string]bool{
+		"application/json":                true,
+		"application/json; charset=utf-8": true,
+		"application/problem+json":        true,
+		"application/vnd.api+json":        true,
+		"application/jsonx":               false,
+		"text/plain":                      false,
+		"application/*":                   false,
+		"":                                false,
+	}
+	for contentType, want := range tests {
+		if got := isJSONMediaType(contentType); got != want {
+			t.Errorf("isJSONMediaType(%q) = %t, want %t", contentType, got, want)
+		}
+	}
+}
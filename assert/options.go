@@ -0,0 +1,67 @@
+package assert
+
+import "github.com/google/go-cmp/cmp"
+
+// Option modifies the behavior of an assertion. Options may be passed to an
+// assertion mixed in with msgAndArgs, and are ignored by assertions to which
+// they do not apply.
+type Option func(*options)
+
+type options struct {
+	collapseMaps  bool
+	diffAlgorithm DiffAlgorithm
+	diffContext   int
+	jsonPatch     bool
+	jsonSummary   bool
+	diffDir       string
+	diffThreshold int
+	breadcrumbs   bool
+	compare       Options
+	cmp           bool
+	cmpOptions    []cmp.Option
+	color         bool
+	sideBySide    bool
+	wordDiff      bool
+	maxDiffLines  int
+
+	ignoreTrailingSlash bool
+	csvHeader           bool
+	csvUnorderedColumns bool
+	sqlPlaceholders     bool
+	unorderedArrays     bool
+	ignoredJSONPaths    []string
+	jsonNumberTolerance float64
+	htmlWhitespace      bool
+	htmlAttributeOrder  bool
+	htmlComments        bool
+	htmlSelector        string
+	xhtml               bool
+
+	// reportExpected and reportActual are the dumps of the values being
+	// compared, recorded for the failure report.
+	reportExpected, reportActual string
+}
+
+// WithCollapsedMaps causes struct dumps to show only the changed, added, or
+// removed entries of any map, replacing the remaining entries with a
+// "(N unchanged entries)" marker.
+func WithCollapsedMaps() Option {
+	return func(o *options) {
+		o.collapseMaps = true
+	}
+}
+
+// parseOptions separates any Options from msgAndArgs, returning the
+// resulting options and the remaining message and arguments.
+func parseOptions(msgAndArgs []interface{}) (*options, []interface{}) {
+	o := &options{diffContext: defaultDiffContext}
+	var rest []interface{}
+	for _, arg := range msgAndArgs {
+		if opt, ok := arg.(Option); ok {
+			opt(o)
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return o, rest
+}
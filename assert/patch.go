@@ -0,0 +1,135 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PatchEqual asserts that the two strings, which must be in unified diff
+// format, describe the same changes. Context lines and hunk header line
+// numbers are ignored, so that patches generated with differing amounts of
+// context, or against slightly shifted sources, compare equal so long as they
+// add and remove the same lines of the same files.
+func PatchEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, err := normalizePatch(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid expected patch: %s", err), msgAndArgs...)
+	}
+	act, err := normalizePatch(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid actual patch: %s", err), msgAndArgs...)
+	}
+	if exp == act {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "Patches differ", exp, act, o, msgAndArgs...)
+}
+
+// PatchEqual asserts that the two strings, which must be in unified diff
+// format, describe the same changes. Context lines and hunk header line
+// numbers are ignored, so that patches generated with differing amounts of
+// context, or against slightly shifted sources, compare equal so long as they
+// add and remove the same lines of the same files.
+func (a *Assertions) PatchEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PatchEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// hunkHeaderRE matches a hunk header, capturing the lengths of the old and
+// new ranges, which are 1 if omitted.
+var hunkHeaderRE = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// normalizePatch parses a unified diff, and re-renders it with the context
+// lines and hunk ranges removed. Each contiguous run of added and removed
+// lines is rendered as its own hunk. The end of each hunk is found from the
+// lengths in its header, so that a removed line such as "-- x", which
+// appears as "--- x", is not mistaken for a file header.
+func normalizePatch(patch string) (string, error) {
+	buf := &bytes.Buffer{}
+	var inChange bool
+	// oldLeft and newLeft are the numbers of lines of the current hunk yet to
+	// be read from the old and new files.
+	var oldLeft, newLeft int
+	writeChange := func(line string) {
+		if !inChange {
+			buf.WriteString("@@\n")
+			inChange = true
+		}
+		buf.WriteString(line + "\n")
+	}
+	lines := strings.Split(strings.TrimSuffix(patch, "\n"), "\n")
+	for i, line := range lines {
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case line == "" || line[0] == ' ':
+				oldLeft, newLeft = oldLeft-1, newLeft-1
+				inChange = false
+				continue
+			case line[0] == '-':
+				oldLeft--
+			case line[0] == '+':
+				newLeft--
+			case line[0] != '\\':
+				return "", errors.Errorf("line %d: unexpected line in hunk: %s", i+1, line)
+			}
+			if oldLeft < 0 || newLeft < 0 {
+				return "", errors.Errorf("line %d: hunk is longer than its header states", i+1)
+			}
+			writeChange(line)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" may follow the last line of a
+			// hunk.
+			writeChange(line)
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			fmt.Fprintf(buf, "--- %s\n", patchFileName(line[4:], "a/"))
+		case strings.HasPrefix(line, "+++ "):
+			fmt.Fprintf(buf, "+++ %s\n", patchFileName(line[4:], "b/"))
+		case strings.HasPrefix(line, "@@"):
+			m := hunkHeaderRE.FindStringSubmatch(line)
+			if m == nil {
+				return "", errors.Errorf("line %d: malformed hunk header: %s", i+1, line)
+			}
+			oldLeft, newLeft = hunkLength(m[1]), hunkLength(m[2])
+			inChange = false
+		default:
+			// Anything outside of a hunk, such as git's "diff --git" and
+			// "index" lines, is not part of the change.
+		}
+	}
+	if oldLeft > 0 || newLeft > 0 {
+		return "", errors.New("last hunk is shorter than its header states")
+	}
+	return buf.String(), nil
+}
+
+// hunkLength returns the length of a hunk range, as captured by hunkHeaderRE.
+func hunkLength(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// patchFileName strips timestamps and the conventional a/ or b/ prefix from a
+// file name in a patch header.
+func patchFileName(name, prefix string) string {
+	if i := strings.Index(name, "\t"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.TrimPrefix(name, prefix)
+}
@@ -0,0 +1,133 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+		err   string
+	}{
+		{
+			name:  "context and ranges dropped",
+			patch: "--- a/f.txt\t2020-01-01\n+++ b/f.txt\n@@ -1,3 +1,3 @@\n a\n-b\n+c\n d\n",
+			want:  "--- f.txt\n+++ f.txt\n@@\n-b\n+c\n",
+		},
+		{
+			name:  "one hunk per change run",
+			patch: "--- f\n+++ f\n@@ -1,4 +1,4 @@\n-a\n+b\n c\n d\n-e\n+f\n",
+			want:  "--- f\n+++ f\n@@\n-a\n+b\n@@\n-e\n+f\n",
+		},
+		{
+			name:  "git headers ignored",
+			patch: "diff --git a/f b/f\nindex 1234..5678 100644\n--- a/f\n+++ b/f\n@@ -1 +1 @@\n-a\n+b\n",
+			want:  "--- f\n+++ f\n@@\n-a\n+b\n",
+		},
+		{
+			name:  "removed line resembling a file header",
+			patch: "--- f\n+++ f\n@@ -1,2 +1,2 @@\n--- x\n+++ y\n z\n",
+			want:  "--- f\n+++ f\n@@\n--- x\n+++ y\n",
+		},
+		{
+			name:  "no newline at end of file",
+			patch: "--- f\n+++ f\n@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+b\n",
+			want:  "--- f\n+++ f\n@@\n-a\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name:  "no newline after last hunk line",
+			patch: "--- f\n+++ f\n@@ -1 +1 @@\n-a\n+b\n\\ No newline at end of file\n",
+			want:  "--- f\n+++ f\n@@\n-a\n+b\n\\ No newline at end of file\n",
+		},
+		{
+			name:  "malformed hunk header",
+			patch: "--- f\n+++ f\n@@ -x +1 @@\n-a\n",
+			err:   "line 3: malformed hunk header",
+		},
+		{
+			name:  "unexpected line in hunk",
+			patch: "--- f\n+++ f\n@@ -1,2 +1,2 @@\n a\n*b\n",
+			err:   "line 5: unexpected line in hunk: *b",
+		},
+		{
+			name:  "hunk longer than header",
+			patch: "--- f\n+++ f\n@@ -1,2 +1,1 @@\n a\n+b\n",
+			err:   "line 5: hunk is longer than its header states",
+		},
+		{
+			name:  "hunk shorter than header",
+			patch: "--- f\n+++ f\n@@ -1,3 +1,3 @@\n a\n",
+			err:   "last hunk is shorter than its header states",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizePatch(tt.patch)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		want             string
+	}{
+		{
+			name:     "shifted lines and differing context",
+			expected: "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b\n+c\n d\n",
+			actual:   "--- a/f\n+++ b/f\n@@ -10,6 +12,6 @@\n w\n x\n a\n-b\n+c\n d\n e\n",
+		},
+		{
+			name:     "differing added line",
+			expected: "--- f\n+++ f\n@@ -1 +1 @@\n-a\n+b\n",
+			actual:   "--- f\n+++ f\n@@ -1 +1 @@\n-a\n+c\n",
+			want:     "Patches differ",
+		},
+		{
+			name:     "differing file",
+			expected: "--- f\n+++ f\n@@ -1 +1 @@\n-a\n+b\n",
+			actual:   "--- g\n+++ g\n@@ -1 +1 @@\n-a\n+b\n",
+			want:     "Patches differ",
+		},
+		{
+			name:     "invalid expected",
+			expected: "--- f\n+++ f\n@@ nonsense @@\n",
+			actual:   "--- f\n+++ f\n@@ -1 +1 @@\n-a\n+b\n",
+			want:     "Invalid expected patch: line 3: malformed hunk header",
+		},
+		{
+			name:     "invalid actual",
+			expected: "--- f\n+++ f\n@@ -1 +1 @@\n-a\n+b\n",
+			actual:   "--- f\n+++ f\n@@ -1,2 +1,2 @@\n-a\n",
+			want:     "Invalid actual patch: last hunk is shorter than its header states",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := PatchEqual(m, tt.expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("PatchEqual returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
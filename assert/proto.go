@@ -0,0 +1,40 @@
+package assert
+
+import (
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoEqual asserts that the two protocol buffer messages are equal, as
+// determined by proto.Equal. On failure, a diff of the messages in text
+// format is shown.
+func ProtoEqual(t TestingT, expected, actual proto.Message, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if proto.Equal(expected, actual) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "Protocol buffer messages differ", protoText(expected), protoText(actual), o, msgAndArgs...)
+}
+
+// ProtoEqual asserts that the two protocol buffer messages are equal, as
+// determined by proto.Equal. On failure, a diff of the messages in text
+// format is shown.
+func (a *Assertions) ProtoEqual(expected, actual proto.Message, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ProtoEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// protoText renders m in multi-line text format. Both sides of a diff are
+// rendered by the same binary, so prototext's deliberately unstable spacing
+// is consistent between them.
+func protoText(m proto.Message) string {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return "<nil>"
+	}
+	return prototext.MarshalOptions{Multiline: true, Indent: "  "}.Format(m)
+}
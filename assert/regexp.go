@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RegexpMatchesLines asserts that each line of actual matches the regular
+// expression at the same position in patterns, and that actual has exactly
+// as many lines as there are patterns. Each pattern must match the whole of
+// its line. A single trailing newline in actual is ignored. On failure, a
+// line-by-line listing is shown, in which each line that fails to match is
+// preceded by the pattern it failed.
+func RegexpMatchesLines(t TestingT, patterns []string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return Fail(t, fmt.Sprintf("Invalid pattern %d: %s", i+1, err), msgAndArgs...)
+		}
+		res[i] = re
+	}
+	lines := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	if actual == "" {
+		lines = nil
+	}
+	buf := &bytes.Buffer{}
+	var failed []string
+	for i := 0; i < len(res) || i < len(lines); i++ {
+		switch {
+		case i >= len(lines):
+			failed = append(failed, fmt.Sprintf("%d", i+1))
+			fmt.Fprintf(buf, "-%s\n", patterns[i])
+		case i >= len(res):
+			failed = append(failed, fmt.Sprintf("%d", i+1))
+			fmt.Fprintf(buf, "+%s\n", lines[i])
+		case res[i].MatchString(lines[i]):
+			fmt.Fprintf(buf, " %s\n", lines[i])
+		default:
+			failed = append(failed, fmt.Sprintf("%d", i+1))
+			fmt.Fprintf(buf, "-%s\n+%s\n", patterns[i], lines[i])
+		}
+	}
+	if len(failed) == 0 {
+		return true
+	}
+	msg := fmt.Sprintf("Lines do not match patterns: %s", strings.Join(failed, ", "))
+	if len(lines) != len(res) {
+		msg += fmt.Sprintf("\nexpected %d lines, got %d", len(res), len(lines))
+	}
+	return FailDiff(t, msg, "--- patterns\n+++ actual\n"+buf.String(), msgAndArgs...)
+}
+
+// RegexpMatchesLines asserts that each line of actual matches the regular
+// expression at the same position in patterns, and that actual has exactly
+// as many lines as there are patterns. Each pattern must match the whole of
+// its line. A single trailing newline in actual is ignored. On failure, a
+// line-by-line listing is shown, in which each line that fails to match is
+// preceded by the pattern it failed.
+func (a *Assertions) RegexpMatchesLines(patterns []string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpMatchesLines(a.t, patterns, actual, a.withOptions(msgAndArgs)...)
+}
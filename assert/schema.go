@@ -0,0 +1,165 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// DeepEqualIgnoringNewFields asserts that newer is deeply equal to older,
+// considering only those fields which exist in older's type. This is useful
+// when comparing values produced by two versions of a schema, where the newer
+// version may have gained fields. Fields are matched by name, at any depth.
+// Values of the same type in both, such as time.Time, are compared whole;
+// otherwise, unexported fields are not compared.
+func DeepEqualIgnoringNewFields(t TestingT, older, newer interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	typ := reflect.TypeOf(older)
+	if typ == nil {
+		return DeepEqual(t, older, newer, msgAndArgs...)
+	}
+	expected, err := newProjector().project(typ, reflect.ValueOf(older))
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Cannot project %T: %s", older, err), msgAndArgs...)
+	}
+	actual, err := newProjector().project(typ, reflect.ValueOf(newer))
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Cannot compare %T to %T: %s", newer, older, err), msgAndArgs...)
+	}
+	return DeepEqual(t, expected.Interface(), actual.Interface(), msgAndArgs...)
+}
+
+// DeepEqualIgnoringNewFields asserts that newer is deeply equal to older,
+// considering only those fields which exist in older's type. This is useful
+// when comparing values produced by two versions of a schema, where the newer
+// version may have gained fields. Fields are matched by name, at any depth.
+// Values of the same type in both, such as time.Time, are compared whole;
+// otherwise, unexported fields are not compared.
+func (a *Assertions) DeepEqualIgnoringNewFields(older, newer interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DeepEqualIgnoringNewFields(a.t, older, newer, a.withOptions(msgAndArgs)...)
+}
+
+// projectedRef identifies a pointer which has been projected to a type.
+type projectedRef struct {
+	ref
+	typ reflect.Type
+}
+
+// projector projects values to the types of an older schema.
+type projector struct {
+	// pointers holds the projection of each pointer already visited, so that
+	// shared and cyclic references are projected only once.
+	pointers map[projectedRef]reflect.Value
+}
+
+func newProjector() *projector {
+	return &projector{pointers: make(map[projectedRef]reflect.Value)}
+}
+
+// project returns a copy of v as type typ, populating only the exported
+// struct fields which exist in typ. Values whose type is typ are copied
+// whole.
+func (p *projector) project(typ reflect.Type, v reflect.Value) (reflect.Value, error) {
+	result := reflect.New(typ).Elem()
+	if !v.IsValid() {
+		return result, nil
+	}
+	if typ.Kind() == reflect.Interface {
+		if !v.Type().AssignableTo(typ) {
+			return result, errors.Errorf("%s is not assignable to %s", v.Type(), typ)
+		}
+		result.Set(v)
+		return result, nil
+	}
+	v = elem(v)
+	if v.Type() == typ {
+		result.Set(v)
+		return result, nil
+	}
+	if v.Kind() != typ.Kind() {
+		return result, errors.Errorf("%s cannot be compared to %s", v.Type(), typ)
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			sf, ok := v.Type().FieldByName(field.Name)
+			if !ok {
+				return result, errors.Errorf("field %s missing from %s", field.Name, v.Type())
+			}
+			fv, err := v.FieldByIndexErr(sf.Index)
+			if err != nil {
+				// The field is promoted through a nil embedded pointer, so
+				// it is taken to be unset.
+				fv = reflect.Zero(sf.Type)
+			}
+			pv, err := p.project(field.Type, fv)
+			if err != nil {
+				return result, errors.Wrap(err, field.Name)
+			}
+			result.Field(i).Set(pv)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return result, nil
+		}
+		key := projectedRef{ref: refOf(v), typ: typ}
+		if pv, ok := p.pointers[key]; ok {
+			return pv, nil
+		}
+		result.Set(reflect.New(typ.Elem()))
+		p.pointers[key] = result
+		pv, err := p.project(typ.Elem(), v.Elem())
+		if err != nil {
+			return result, err
+		}
+		result.Elem().Set(pv)
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return result, nil
+			}
+			result.Set(reflect.MakeSlice(typ, v.Len(), v.Len()))
+		} else if v.Len() != typ.Len() {
+			return result, errors.Errorf("%s cannot be compared to %s", v.Type(), typ)
+		}
+		for i := 0; i < v.Len(); i++ {
+			pv, err := p.project(typ.Elem(), v.Index(i))
+			if err != nil {
+				return result, errors.Wrapf(err, "[%d]", i)
+			}
+			result.Index(i).Set(pv)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return result, nil
+		}
+		result.Set(reflect.MakeMap(typ))
+		for _, key := range v.MapKeys() {
+			pk, err := p.project(typ.Key(), key)
+			if err != nil {
+				return result, err
+			}
+			pv, err := p.project(typ.Elem(), v.MapIndex(key))
+			if err != nil {
+				return result, errors.Wrapf(err, "[%v]", key)
+			}
+			result.SetMapIndex(pk, pv)
+		}
+	default:
+		if !v.Type().ConvertibleTo(typ) {
+			return result, errors.Errorf("%s cannot be compared to %s", v.Type(), typ)
+		}
+		result.Set(v.Convert(typ))
+	}
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaAddressV1 struct {
+	City string
+}
+
+type schemaAddressV2 struct {
+	City string
+	Zip  string
+}
+
+type schemaUserV1 struct {
+	ID      int
+	Name    string
+	Created time.Time
+	Address schemaAddressV1
+	Tags    []string
+	Friend  *schemaUserV1
+}
+
+type schemaUserV2 struct {
+	ID      int
+	Name    string
+	Email   string
+	Created time.Time
+	Address schemaAddressV2
+	Tags    []string
+	Friend  *schemaUserV2
+}
+
+type schemaBase struct {
+	Name string
+}
+
+type schemaEmbedded struct {
+	*schemaBase
+	ID int
+}
+
+type schemaFlat struct {
+	Name string
+	ID   int
+}
+
+type schemaRenamed struct {
+	ID       int
+	FullName string
+}
+
+func TestDeepEqualIgnoringNewFields(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	older := schemaUserV1{
+		ID:      1,
+		Name:    "Bob",
+		Created: created,
+		Address: schemaAddressV1{City: "Paris"},
+		Tags:    []string{"a"},
+	}
+	newer := schemaUserV2{
+		ID:      1,
+		Name:    "Bob",
+		Email:   "bob@example.com",
+		Created: created,
+		Address: schemaAddressV2{City: "Paris", Zip: "75001"},
+		Tags:    []string{"a"},
+	}
+	cycleV1 := &schemaUserV1{ID: 1}
+	cycleV1.Friend = cycleV1
+	cycleV2 := &schemaUserV2{ID: 1, Email: "x"}
+	cycleV2.Friend = cycleV2
+
+	tests := []struct {
+		name         string
+		older, newer interface{}
+		want         string
+	}{
+		{
+			name:  "new fields ignored",
+			older: older,
+			newer: newer,
+		},
+		{
+			name:  "pointers",
+			older: &older,
+			newer: &newer,
+		},
+		{
+			name:  "cycles",
+			older: cycleV1,
+			newer: cycleV2,
+		},
+		{
+			name:  "slice of structs",
+			older: []schemaAddressV1{{City: "Paris"}, {City: "Rome"}},
+			newer: []schemaAddressV2{{City: "Paris", Zip: "1"}, {City: "Rome"}},
+		},
+		{
+			name:  "nil embedded pointer",
+			older: schemaFlat{ID: 1},
+			newer: schemaEmbedded{ID: 1},
+		},
+		{
+			name:  "embedded pointer",
+			older: schemaFlat{ID: 1, Name: "Bob"},
+			newer: schemaEmbedded{schemaBase: &schemaBase{Name: "Bob"}, ID: 1},
+		},
+		{
+			name:  "field of nil embedded pointer",
+			older: schemaFlat{ID: 1, Name: "Bob"},
+			newer: schemaEmbedded{ID: 1},
+			want:  `-  Name: (string) (len=3) "Bob"`,
+		},
+		{
+			name:  "differing common field",
+			older: older,
+			newer: func() schemaUserV2 { n := newer; n.Name = "Alice"; return n }(),
+			want:  `+  Name: (string) (len=5) "Alice"`,
+		},
+		{
+			name:  "differing nested field",
+			older: older,
+			newer: func() schemaUserV2 { n := newer; n.Address.City = "Rome"; return n }(),
+			want:  `+    City: (string) (len=4) "Rome"`,
+		},
+		{
+			name:  "differing time",
+			older: older,
+			newer: func() schemaUserV2 { n := newer; n.Created = created.Add(27 * time.Hour); return n }(),
+			want:  "+  Created: (time.Time) 2020-01-03T06:04:05Z",
+		},
+		{
+			name:  "removed field",
+			older: schemaRenamed{ID: 1},
+			newer: schemaUserV2{ID: 1},
+			want:  "field FullName missing from assert.schemaUserV2",
+		},
+		{
+			name:  "changed kind",
+			older: struct{ Tags []string }{Tags: []string{"a"}},
+			newer: struct{ Tags string }{Tags: "a"},
+			want:  "Tags: string cannot be compared to []string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := DeepEqualIgnoringNewFields(m, tt.older, tt.newer)
+			if got != (tt.want == "") {
+				t.Fatalf("DeepEqualIgnoringNewFields returned %t:\n%s", got, m.output())
+			}
+			if !strings.Contains(m.output(), tt.want) {
+				t.Errorf("failure does not contain %q:\n%s", tt.want, m.output())
+			}
+		})
+	}
+}
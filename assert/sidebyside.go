@@ -0,0 +1,95 @@
+package assert
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// WithSideBySideDiff causes diffs in failure messages to be rendered in two
+// columns, with the expected value on the left and the actual value on the
+// right, rather than as a unified diff. Each row is prefixed with a marker:
+// "!" for a changed line, "-" for a line only in the expected value, and "+"
+// for a line only in the actual value.
+func WithSideBySideDiff() Option {
+	return func(o *options) {
+		o.sideBySide = true
+	}
+}
+
+// sideBySideRow is a single row of a side-by-side diff.
+type sideBySideRow struct {
+	marker      byte
+	left, right string
+	// hunk, when non-empty, is a hunk header, spanning both columns.
+	hunk string
+}
+
+// sideBySideDiff renders the grouped opcodes as a side-by-side diff of a and
+// b.
+func sideBySideDiff(a, b []string, groups [][]difflib.OpCode) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	var rows []sideBySideRow
+	for _, g := range groups {
+		first, last := g[0], g[len(g)-1]
+		rows = append(rows, sideBySideRow{
+			hunk: "@@ -" + unifiedRange(first.I1, last.I2) + " +" + unifiedRange(first.J1, last.J2) + " @@",
+		})
+		for _, c := range g {
+			left, right := a[c.I1:c.I2], b[c.J1:c.J2]
+			for i := 0; i < len(left) || i < len(right); i++ {
+				row := sideBySideRow{marker: ' '}
+				switch {
+				case c.Tag == 'e':
+				case i >= len(left):
+					row.marker = '+'
+				case i >= len(right):
+					row.marker = '-'
+				default:
+					row.marker = '!'
+				}
+				if i < len(left) {
+					row.left = sideBySideLine(left[i])
+				}
+				if i < len(right) {
+					row.right = sideBySideLine(right[i])
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	const header = "--- expected"
+	width := utf8.RuneCountInString(header) - 2
+	for _, row := range rows {
+		if row.hunk == "" {
+			width = maxInt(width, utf8.RuneCountInString(row.left))
+		}
+	}
+	pad := func(s string, n int) string {
+		return s + strings.Repeat(" ", n-utf8.RuneCountInString(s))
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(pad(header, width+2) + " | +++ actual\n")
+	for _, row := range rows {
+		if row.hunk != "" {
+			buf.WriteString(row.hunk + "\n")
+			continue
+		}
+		line := string(row.marker) + " " + pad(row.left, width) + " | " + row.right
+		buf.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return buf.String()
+}
+
+// sideBySideLine prepares a line for display in a column, removing its line
+// terminator and expanding tabs, so that the columns remain aligned.
+func sideBySideLine(line string) string {
+	line = strings.TrimSuffix(line, "\n")
+	return strings.Replace(line, "\t", "    ", -1)
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// SimilarityAtLeast asserts that the similarity ratio of expected and actual
+// is at least minRatio. The ratio, between 0 and 1, is computed by difflib's
+// sequence matcher over the characters of the two strings, where 1 means the
+// strings are identical. This is useful for output which should be "mostly"
+// the same.
+func SimilarityAtLeast(t TestingT, expected, actual string, minRatio float64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	ratio := similarity(expected, actual)
+	if ratio >= minRatio {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, fmt.Sprintf("Similarity ratio %.4f is less than %.4f", ratio, minRatio), expected, actual, o, msgAndArgs...)
+}
+
+// SimilarityAtLeast asserts that the similarity ratio of expected and actual
+// is at least minRatio. The ratio, between 0 and 1, is computed by difflib's
+// sequence matcher over the characters of the two strings, where 1 means the
+// strings are identical. This is useful for output which should be "mostly"
+// the same.
+func (a *Assertions) SimilarityAtLeast(expected, actual string, minRatio float64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return SimilarityAtLeast(a.t, expected, actual, minRatio, a.withOptions(msgAndArgs)...)
+}
+
+// similarity returns the difflib similarity ratio of the characters of a and
+// b.
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	// Disable the autojunk heuristic, which would otherwise discard common
+	// characters in long strings, and skew the ratio.
+	return difflib.NewMatcherWithJunk(splitChars(a), splitChars(b), false, nil).Ratio()
+}
+
+func splitChars(s string) []string {
+	chars := make([]string, 0, len(s))
+	for _, r := range s {
+		chars = append(chars, string(r))
+	}
+	return chars
+}
@@ -0,0 +1,83 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"abc", "abc", 1},
+		{"abc", "", 0},
+		{"abc", "xyz", 0},
+		{"abcd", "abce", 0.75},
+		{"héllo", "hello", 0.8},
+		// Without autojunk, the common characters of long strings are not
+		// discarded.
+		{strings.Repeat("a", 300), strings.Repeat("a", 299) + "b", 299.0 / 300},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarityAtLeast(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		minRatio         float64
+		want             string
+	}{
+		{
+			name:     "identical",
+			expected: "abcd",
+			actual:   "abcd",
+			minRatio: 1,
+		},
+		{
+			name:     "at the boundary",
+			expected: "abcd",
+			actual:   "abce",
+			minRatio: 0.75,
+		},
+		{
+			name:     "just below the boundary",
+			expected: "abcd",
+			actual:   "abce",
+			minRatio: 0.7501,
+			want:     "Similarity ratio 0.7500 is less than 0.7501",
+		},
+		{
+			name:     "diff reported",
+			expected: "abcd\n",
+			actual:   "abce\n",
+			minRatio: 0.9,
+			want:     "-abcd\n+abce",
+		},
+		{
+			name:     "zero ratio always passes",
+			expected: "abc",
+			actual:   "xyz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := SimilarityAtLeast(m, tt.expected, tt.actual, tt.minRatio)
+			if got != (tt.want == "") {
+				t.Fatalf("SimilarityAtLeast returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ContainsInOrder asserts that the elements of expectedSubsequence appear
+// within actual in the same order, though not necessarily contiguously, so
+// actual may contain extra interleaved elements. Both arguments must be
+// slices or arrays. Elements are compared with reflect.DeepEqual.
+func ContainsInOrder(t TestingT, actual, expectedSubsequence interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	act, ok := sliceValue(actual)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", actual), msgAndArgs...)
+	}
+	exp, ok := sliceValue(expectedSubsequence)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", expectedSubsequence), msgAndArgs...)
+	}
+	i, lastMatch := 0, -1
+	for j := 0; j < exp.Len(); j++ {
+		want := exp.Index(j).Interface()
+		for ; i < act.Len(); i++ {
+			if reflect.DeepEqual(want, act.Index(i).Interface()) {
+				break
+			}
+		}
+		if i == act.Len() {
+			stalled := "No elements were matched"
+			if lastMatch >= 0 {
+				stalled = fmt.Sprintf("Matching stalled after actual[%d]", lastMatch)
+			}
+			return Fail(t, fmt.Sprintf("Element %d of expected subsequence not found in order: %#v\n%s (%d of %d elements matched)",
+				j, want, stalled, j, exp.Len()), msgAndArgs...)
+		}
+		lastMatch = i
+		i++
+	}
+	return true
+}
+
+// ContainsInOrder asserts that the elements of expectedSubsequence appear
+// within actual in the same order, though not necessarily contiguously, so
+// actual may contain extra interleaved elements. Both arguments must be
+// slices or arrays. Elements are compared with reflect.DeepEqual.
+func (a *Assertions) ContainsInOrder(actual, expectedSubsequence interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ContainsInOrder(a.t, actual, expectedSubsequence, a.withOptions(msgAndArgs)...)
+}
+
+// EqualByKey asserts that the expected and actual slices are equal when each
+// element is projected through keyFn. This allows, for instance, comparing two
+// slices of users by their IDs alone.
+func EqualByKey(t TestingT, expected, actual interface{}, keyFn func(interface{}) interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, ok := sliceValue(expected)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", expected), msgAndArgs...)
+	}
+	act, ok := sliceValue(actual)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", actual), msgAndArgs...)
+	}
+	expKeys, actKeys := projectKeys(exp, keyFn), projectKeys(act, keyFn)
+	if reflect.DeepEqual(expKeys, actKeys) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failInterfaceDiff(t, "Keys differ", expKeys, actKeys, o, msgAndArgs...)
+}
+
+// EqualByKey asserts that the expected and actual slices are equal when each
+// element is projected through keyFn. This allows, for instance, comparing two
+// slices of users by their IDs alone.
+func (a *Assertions) EqualByKey(expected, actual interface{}, keyFn func(interface{}) interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualByKey(a.t, expected, actual, keyFn, a.withOptions(msgAndArgs)...)
+}
+
+func projectKeys(v reflect.Value, keyFn func(interface{}) interface{}) []interface{} {
+	keys := make([]interface{}, v.Len())
+	for i := range keys {
+		keys[i] = keyFn(v.Index(i).Interface())
+	}
+	return keys
+}
+
+// SlicesEqualFunc asserts that the expected and actual slices have the same
+// length, and that eq returns true for each pair of corresponding elements.
+// This allows arbitrary per-element rules, such as numeric tolerances. On
+// failure, the first index at which eq returned false is reported.
+func SlicesEqualFunc(t TestingT, expected, actual interface{}, eq func(a, b interface{}) bool, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, ok := sliceValue(expected)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", expected), msgAndArgs...)
+	}
+	act, ok := sliceValue(actual)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a slice or array", actual), msgAndArgs...)
+	}
+	for i := 0; i < exp.Len() && i < act.Len(); i++ {
+		e, a := exp.Index(i).Interface(), act.Index(i).Interface()
+		if !eq(e, a) {
+			return Fail(t, fmt.Sprintf("Elements at index %d differ:\nexpected: %#v\nactual  : %#v", i, e, a), msgAndArgs...)
+		}
+	}
+	if exp.Len() != act.Len() {
+		return Fail(t, fmt.Sprintf("Lengths differ: expected %d, actual %d", exp.Len(), act.Len()), msgAndArgs...)
+	}
+	return true
+}
+
+// SlicesEqualFunc asserts that the expected and actual slices have the same
+// length, and that eq returns true for each pair of corresponding elements.
+// This allows arbitrary per-element rules, such as numeric tolerances. On
+// failure, the first index at which eq returned false is reported.
+func (a *Assertions) SlicesEqualFunc(expected, actual interface{}, eq func(a, b interface{}) bool, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return SlicesEqualFunc(a.t, expected, actual, eq, a.withOptions(msgAndArgs)...)
+}
+
+// sliceValue returns the reflect.Value of i, if it is a slice or array.
+func sliceValue(i interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v, true
+	}
+	return v, false
+}
@@ -0,0 +1,214 @@
+package assert
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestContainsInOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+		want     string
+	}{
+		{
+			name:     "empty subsequence",
+			actual:   []string{"a"},
+			expected: []string{},
+		},
+		{
+			name:     "contiguous",
+			actual:   []string{"a", "b", "c"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "interleaved",
+			actual:   []string{"start", "a", "x", "b", "y", "c", "end"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "repeated elements",
+			actual:   []int{1, 1, 2, 1},
+			expected: []int{1, 1, 1},
+		},
+		{
+			name:     "array",
+			actual:   [3]int{1, 2, 3},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "out of order",
+			actual:   []string{"a", "x", "c", "b"},
+			expected: []string{"a", "b", "c"},
+			want:     "Element 2 of expected subsequence not found in order: \"c\"\nMatching stalled after actual[3] (2 of 3 elements matched)",
+		},
+		{
+			name:     "first element missing",
+			actual:   []string{"x", "y"},
+			expected: []string{"a"},
+			want:     "Element 0 of expected subsequence not found in order: \"a\"\nNo elements were matched (0 of 1 elements matched)",
+		},
+		{
+			name:     "too few repetitions",
+			actual:   []int{1, 2, 1},
+			expected: []int{1, 1, 1},
+			want:     "Element 2 of expected subsequence not found in order: 1\nMatching stalled after actual[2]",
+		},
+		{
+			name:     "not a slice",
+			actual:   "abc",
+			expected: []string{"a"},
+			want:     "string is not a slice or array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := ContainsInOrder(m, tt.actual, tt.expected)
+			if got != (tt.want == "") {
+				t.Fatalf("ContainsInOrder returned %t:\n%s", got, m.output())
+			}
+			// Fail indents the lines of the message, so each is sought
+			// separately.
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+type keyedUser struct {
+	ID   int
+	Name string
+}
+
+func TestEqualByKey(t *testing.T) {
+	id := func(u interface{}) interface{} { return u.(keyedUser).ID }
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		want             string
+	}{
+		{
+			name:     "other fields ignored",
+			expected: []keyedUser{{1, "alice"}, {2, "bob"}},
+			actual:   []keyedUser{{1, "Alice"}, {2, "Bob"}},
+		},
+		{
+			name:     "arrays",
+			expected: [2]keyedUser{{1, "alice"}, {2, "bob"}},
+			actual:   []keyedUser{{1, "alice"}, {2, "bob"}},
+		},
+		{
+			name:     "differing key",
+			expected: []keyedUser{{1, "alice"}, {2, "bob"}},
+			actual:   []keyedUser{{1, "alice"}, {3, "bob"}},
+			want:     "Keys differ\n-  (int) 2\n+  (int) 3",
+		},
+		{
+			name:     "order is significant",
+			expected: []keyedUser{{1, "alice"}, {2, "bob"}},
+			actual:   []keyedUser{{2, "bob"}, {1, "alice"}},
+			want:     "Keys differ",
+		},
+		{
+			name:     "missing element",
+			expected: []keyedUser{{1, "alice"}, {2, "bob"}},
+			actual:   []keyedUser{{1, "alice"}},
+			want:     "Keys differ\n-  (int) 2",
+		},
+		{
+			name:     "expected not a slice",
+			expected: keyedUser{1, "alice"},
+			actual:   []keyedUser{{1, "alice"}},
+			want:     "assert.keyedUser is not a slice or array",
+		},
+		{
+			name:     "actual not a slice",
+			expected: []keyedUser{{1, "alice"}},
+			actual:   map[int]keyedUser{1: {1, "alice"}},
+			want:     "map[int]assert.keyedUser is not a slice or array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := EqualByKey(m, tt.expected, tt.actual, id)
+			if got != (tt.want == "") {
+				t.Fatalf("EqualByKey returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestSlicesEqualFunc(t *testing.T) {
+	within := func(a, b interface{}) bool { return math.Abs(a.(float64)-b.(float64)) < 0.01 }
+	tests := []struct {
+		name             string
+		expected, actual interface{}
+		want             string
+	}{
+		{
+			name:     "empty",
+			expected: []float64{},
+			actual:   []float64(nil),
+		},
+		{
+			name:     "within tolerance",
+			expected: []float64{1, 2},
+			actual:   []float64{1.001, 1.999},
+		},
+		{
+			name:     "array and slice",
+			expected: [2]float64{1, 2},
+			actual:   []float64{1, 2},
+		},
+		{
+			name:     "outside tolerance",
+			expected: []float64{1, 2, 3},
+			actual:   []float64{1.001, 2.1, 4},
+			want:     "Elements at index 1 differ:\nexpected: 2\nactual  : 2.1",
+		},
+		{
+			name:     "shorter actual",
+			expected: []float64{1, 2},
+			actual:   []float64{1.001},
+			want:     "Lengths differ: expected 2, actual 1",
+		},
+		{
+			name:     "element difference reported before length",
+			expected: []float64{1, 2},
+			actual:   []float64{5},
+			want:     "Elements at index 0 differ",
+		},
+		{
+			name:     "not a slice",
+			expected: []float64{1},
+			actual:   1.0,
+			want:     "float64 is not a slice or array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := SlicesEqualFunc(m, tt.expected, tt.actual, within)
+			if got != (tt.want == "") {
+				t.Fatalf("SlicesEqualFunc returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/flimzy/testify/internal/update"
+)
+
+// snapshotDir is the directory, relative to the package being tested, in
+// which snapshots are stored.
+var snapshotDir = filepath.Join("testdata", "snapshots")
+
+// snapshotConfig dumps values as spewConfig does, but without pointer
+// addresses or capacities, which vary from run to run.
+var snapshotConfig = func() *spew.ConfigState {
+	c := spewConfig
+	c.DisablePointerAddresses = true
+	c.DisableCapacities = true
+	return &c
+}()
+
+// Snapshot asserts that the dump of value matches the snapshot stored for the
+// test, in testdata/snapshots/<test name>.snap, where subtests are separated
+// by underscores. The value is dumped by spew, as for the diffs of DeepEqual,
+// but without pointer addresses or capacities, so that it is stable from run
+// to run. When the tests are run with the -update flag, or with
+// TESTIFY_UPDATE=1, the snapshot is instead written, and the assertion
+// passes. A test may have only one snapshot; use subtests for more.
+func Snapshot(t TestingT, value interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	n, ok := t.(namer)
+	if !ok {
+		return Fail(t, fmt.Sprintf("Snapshot requires a TestingT with a Name method, such as *testing.T, not %T", t), msgAndArgs...)
+	}
+	path := filepath.Join(snapshotDir, unsafeFileChars.ReplaceAllString(n.Name(), "_")+".snap")
+	actual := snapshotConfig.Sdump(value)
+	if update.Enabled() {
+		if err := update.Write(path, []byte(actual)); err != nil {
+			return Fail(t, fmt.Sprintf("Error updating snapshot: %s", err), msgAndArgs...)
+		}
+		return true
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Fail(t, fmt.Sprintf("Snapshot %s does not exist; run the tests with -update or TESTIFY_UPDATE=1 to create it", path), msgAndArgs...)
+		}
+		return Fail(t, fmt.Sprintf("Error reading snapshot: %s", err), msgAndArgs...)
+	}
+	if string(expected) == actual {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, fmt.Sprintf("Value differs from snapshot %s", path), string(expected), actual, o, msgAndArgs...)
+}
+
+// Snapshot asserts that the dump of value matches the snapshot stored for the
+// test, in testdata/snapshots/<test name>.snap, where subtests are separated
+// by underscores. The value is dumped by spew, as for the diffs of DeepEqual,
+// but without pointer addresses or capacities, so that it is stable from run
+// to run. When the tests are run with the -update flag, or with
+// TESTIFY_UPDATE=1, the snapshot is instead written, and the assertion
+// passes. A test may have only one snapshot; use subtests for more.
+func (a *Assertions) Snapshot(value interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Snapshot(a.t, value, a.withOptions(msgAndArgs)...)
+}
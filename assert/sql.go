@@ -0,0 +1,254 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WithNormalizedPlaceholders causes SQLEqual to treat all query placeholders,
+// such as $1, ?, :name and @name, as equivalent.
+func WithNormalizedPlaceholders() Option {
+	return func(o *options) {
+		o.sqlPlaceholders = true
+	}
+}
+
+// SQLEqual asserts that the two SQL statements are equivalent once
+// normalized, so that differences in whitespace, comments, keyword case and
+// trailing semicolons are ignored. The WithNormalizedPlaceholders option may
+// be passed to also ignore differences in placeholder style. On failure, a
+// word-level diff of the normalized statements is shown.
+func SQLEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	exp := normalizedSQLTokens(expected, o.sqlPlaceholders)
+	act := normalizedSQLTokens(actual, o.sqlPlaceholders)
+	if strings.Join(exp, "\n") == strings.Join(act, "\n") {
+		return true
+	}
+	msg := fmt.Sprintf("SQL statements differ\nexpected: %s\nactual:   %s", joinSQL(exp), joinSQL(act))
+	return failDiff(t, msg, strings.Join(exp, "\n"), strings.Join(act, "\n"), o, msgAndArgs...)
+}
+
+// SQLEqual asserts that the two SQL statements are equivalent once
+// normalized, so that differences in whitespace, comments, keyword case and
+// trailing semicolons are ignored. The WithNormalizedPlaceholders option may
+// be passed to also ignore differences in placeholder style. On failure, a
+// word-level diff of the normalized statements is shown.
+func (a *Assertions) SQLEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return SQLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// MigrationsEqual asserts that the two lists of SQL migration statements are
+// equivalent. Each statement is normalized before comparison, so that
+// differences in whitespace, comments, keyword case and trailing semicolons
+// are ignored. On failure, the first statement which differs is reported,
+// along with a diff of the normalized statements.
+func MigrationsEqual(t TestingT, expected, actual []string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp := make([]string, len(expected))
+	for i, stmt := range expected {
+		exp[i] = normalizeSQL(stmt)
+	}
+	act := make([]string, len(actual))
+	for i, stmt := range actual {
+		act[i] = normalizeSQL(stmt)
+	}
+	var msg string
+	for i := 0; i < len(exp) || i < len(act); i++ {
+		switch {
+		case i >= len(act):
+			msg = fmt.Sprintf("Migration %d missing", i)
+		case i >= len(exp):
+			msg = fmt.Sprintf("Unexpected migration %d", i)
+		case exp[i] != act[i]:
+			msg = fmt.Sprintf("Migration %d differs", i)
+		default:
+			continue
+		}
+		break
+	}
+	if msg == "" {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, msg, strings.Join(exp, "\n"), strings.Join(act, "\n"), o, msgAndArgs...)
+}
+
+// MigrationsEqual asserts that the two lists of SQL migration statements are
+// equivalent. Each statement is normalized before comparison, so that
+// differences in whitespace, comments, keyword case and trailing semicolons
+// are ignored. On failure, the first statement which differs is reported,
+// along with a diff of the normalized statements.
+func (a *Assertions) MigrationsEqual(expected, actual []string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return MigrationsEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// normalizeSQL returns a canonical rendering of the SQL statement stmt.
+// Comments are removed, runs of whitespace are collapsed, spacing around
+// punctuation is made uniform, and any trailing semicolon is dropped. Unquoted
+// words, which SQL treats case-insensitively whether they are keywords or
+// identifiers, are upper-cased. Quoted strings and identifiers are preserved
+// as-is.
+func normalizeSQL(stmt string) string {
+	return joinSQL(normalizedSQLTokens(stmt, false))
+}
+
+// normalizedSQLTokens returns the tokens of stmt, without any trailing
+// semicolon. If placeholders is true, each placeholder, such as $1, ?, :name
+// or @name, is replaced by ?.
+func normalizedSQLTokens(stmt string, placeholders bool) []string {
+	tokens := sqlTokens(stmt)
+	for len(tokens) > 0 && tokens[len(tokens)-1] == ";" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if !placeholders {
+		return tokens
+	}
+	result := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case len(tok) > 1 && tok[0] == '$' && strings.Trim(tok[1:], "0123456789") == "":
+			tok = "?"
+		case (tok == ":" || tok == "@") && i+1 < len(tokens) && isSQLWordRune(rune(tokens[i+1][0])):
+			tok = "?"
+			i++
+		}
+		result = append(result, tok)
+	}
+	return result
+}
+
+func joinSQL(tokens []string) string {
+	var b strings.Builder
+	for i, tok := range tokens {
+		if i > 0 && sqlSpaceBetween(tokens[i-1], tok) {
+			b.WriteRune(' ')
+		}
+		b.WriteString(tok)
+	}
+	return b.String()
+}
+
+// sqlSpaceBetween reports whether a space separates the tokens prev and next
+// in normalized SQL.
+func sqlSpaceBetween(prev, next string) bool {
+	switch next {
+	case ",", ")", ";", ".", "::", "(":
+		return false
+	}
+	switch prev {
+	case "(", ".", "::":
+		return false
+	}
+	return true
+}
+
+func isSQLWordRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// sqlOperators are the multi-character operators recognized as single
+// tokens.
+var sqlOperators = []string{"<=", ">=", "<>", "!=", "::", "||", "->>", "->"}
+
+// sqlTokens splits stmt into tokens, discarding whitespace and comments.
+func sqlTokens(stmt string) []string {
+	var tokens []string
+	for i := 0; i < len(stmt); {
+		rest := stmt[i:]
+		c, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case strings.HasPrefix(rest, "--"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			i += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				i = len(stmt)
+				continue
+			}
+			i += 2 + end + 2
+		case c == '\'' || c == '"' || c == '`':
+			j := 1
+			for j < len(rest) {
+				if rest[j] == byte(c) {
+					// A doubled quote is an escaped quote.
+					if j+1 < len(rest) && rest[j+1] == byte(c) {
+						j += 2
+						continue
+					}
+					j++
+					break
+				}
+				j++
+			}
+			tokens = append(tokens, rest[:j])
+			i += j
+		case c == '$' && sqlDollarTag(rest) != "":
+			tag := sqlDollarTag(rest)
+			end := strings.Index(rest[len(tag):], tag)
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += 2 * len(tag)
+			}
+			tokens = append(tokens, rest[:end])
+			i += end
+		case isSQLWordRune(c):
+			end := strings.IndexFunc(rest, func(r rune) bool {
+				return !isSQLWordRune(r)
+			})
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, strings.ToUpper(rest[:end]))
+			i += end
+		default:
+			tok := rest[:size]
+			for _, op := range sqlOperators {
+				if strings.HasPrefix(rest, op) && len(op) > len(tok) {
+					tok = op
+				}
+			}
+			tokens = append(tokens, tok)
+			i += len(tok)
+		}
+	}
+	return tokens
+}
+
+// sqlDollarTag returns the PostgreSQL dollar-quote tag, such as $$ or
+// $body$, which begins s, or an empty string if s does not begin with one.
+func sqlDollarTag(s string) string {
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		switch {
+		case c == '$':
+			return s[:j+1]
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		case j > 1 && '0' <= c && c <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
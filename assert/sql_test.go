@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		stmt string
+		want string
+	}{
+		{name: "empty", stmt: "", want: ""},
+		{name: "whitespace collapsed", stmt: "select\n\t*   from  t", want: "SELECT * FROM T"},
+		{name: "trailing semicolons dropped", stmt: "select 1;;", want: "SELECT 1"},
+		{name: "line comment", stmt: "select 1 -- one\n, 2", want: "SELECT 1, 2"},
+		{name: "block comment", stmt: "select /* all */ *", want: "SELECT *"},
+		{name: "unterminated block comment", stmt: "select 1 /* x", want: "SELECT 1"},
+		{name: "punctuation spacing", stmt: "f ( a , b . c ) :: int", want: "F(A, B.C)::INT"},
+		{name: "operators", stmt: "a<=b and c<>d or e->>'k'", want: "A <= B AND C <> D OR E ->> 'k'"},
+		{name: "quoted strings preserved", stmt: "select 'It''s  Here'", want: "SELECT 'It''s  Here'"},
+		{name: "quoted identifiers preserved", stmt: `select "MixedCase", ` + "`x y`", want: `SELECT "MixedCase", ` + "`x y`"},
+		{name: "dollar quoting", stmt: "as $body$ select  1; $body$ language sql", want: "AS $body$ select  1; $body$ LANGUAGE SQL"},
+		{name: "placeholders untouched", stmt: "where a = $1 and b = ?", want: "WHERE A = $1 AND B = ?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeSQL(tt.stmt); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrationsEqual(t *testing.T) {
+	expected := []string{
+		"CREATE TABLE users (id INT PRIMARY KEY, name TEXT NOT NULL);",
+		"ALTER TABLE users ADD COLUMN email VARCHAR(255)",
+		"CREATE FUNCTION f() RETURNS int AS $$ SELECT  1 $$ LANGUAGE sql",
+	}
+	tests := []struct {
+		name   string
+		actual []string
+		want   string
+	}{
+		{
+			name: "reformatted",
+			actual: []string{
+				"create table users(\n  id int primary key, -- the id\n  name text not null\n)",
+				"/* add email */ alter table users add column email varchar( 255 ) ;",
+				"create function f ( ) returns int as $$ SELECT  1 $$ language sql;",
+			},
+		},
+		{
+			name: "differing statement",
+			actual: []string{
+				expected[0],
+				"alter table users add column mail varchar(255)",
+				expected[2],
+			},
+			want: "Migration 1 differs\n-ALTER TABLE USERS ADD COLUMN EMAIL VARCHAR(255)\n+ALTER TABLE USERS ADD COLUMN MAIL VARCHAR(255)",
+		},
+		{
+			name:   "differing string literal",
+			actual: []string{expected[0], expected[1], "CREATE FUNCTION f() RETURNS int AS $$ SELECT 1 $$ LANGUAGE sql"},
+			want:   "Migration 2 differs",
+		},
+		{
+			name:   "missing statement",
+			actual: expected[:2],
+			want:   "Migration 2 missing\n-CREATE FUNCTION F() RETURNS INT AS $$ SELECT  1 $$ LANGUAGE SQL",
+		},
+		{
+			name:   "unexpected statement",
+			actual: append(append([]string(nil), expected...), "DROP TABLE users"),
+			want:   "Unexpected migration 3\n+DROP TABLE USERS",
+		},
+		{
+			name:   "reordered",
+			actual: []string{expected[1], expected[0], expected[2]},
+			want:   "Migration 0 differs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := MigrationsEqual(m, expected, tt.actual)
+			if got != (tt.want == "") {
+				t.Fatalf("MigrationsEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
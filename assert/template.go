@@ -0,0 +1,72 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	texttemplate "text/template"
+
+	"github.com/pkg/errors"
+)
+
+type executor interface {
+	Execute(io.Writer, interface{}) error
+}
+
+// TemplatesEquivalent asserts that the two templates produce equivalent output
+// when executed with the same data. This is useful for verifying that a
+// refactored template is unchanged in effect. The templates may be of type
+// *html/template.Template, in which case the outputs are compared with
+// HTMLEqual, or *text/template.Template, in which case the outputs are
+// compared with LinesEqual.
+func TemplatesEquivalent(t TestingT, tmplA, tmplB, data interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	outA, isHTMLA, err := executeTemplate(tmplA, data)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error executing first template: %s", err), msgAndArgs...)
+	}
+	outB, isHTMLB, err := executeTemplate(tmplB, data)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error executing second template: %s", err), msgAndArgs...)
+	}
+	if isHTMLA && isHTMLB {
+		return HTMLEqual(t, outA, outB, msgAndArgs...)
+	}
+	return LinesEqual(t, outA, outB, msgAndArgs...)
+}
+
+// TemplatesEquivalent asserts that the two templates produce equivalent output
+// when executed with the same data. This is useful for verifying that a
+// refactored template is unchanged in effect. The templates may be of type
+// *html/template.Template, in which case the outputs are compared with
+// HTMLEqual, or *text/template.Template, in which case the outputs are
+// compared with LinesEqual.
+func (a *Assertions) TemplatesEquivalent(tmplA, tmplB, data interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return TemplatesEquivalent(a.t, tmplA, tmplB, data, a.withOptions(msgAndArgs)...)
+}
+
+// executeTemplate executes tmpl with data, and returns the output, and whether
+// tmpl is an HTML template.
+func executeTemplate(tmpl, data interface{}) (string, bool, error) {
+	var exec executor
+	var isHTML bool
+	switch tt := tmpl.(type) {
+	case *htmltemplate.Template:
+		exec, isHTML = tt, true
+	case *texttemplate.Template:
+		exec = tt
+	default:
+		return "", false, errors.Errorf("unsupported template type %T", tmpl)
+	}
+	buf := &bytes.Buffer{}
+	if err := exec.Execute(buf, data); err != nil {
+		return "", false, err
+	}
+	return buf.String(), isHTML, nil
+}
@@ -0,0 +1,98 @@
+package assert
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestTemplatesEquivalent(t *testing.T) {
+	html := func(s string) *htmltemplate.Template {
+		return htmltemplate.Must(htmltemplate.New("").Parse(s))
+	}
+	text := func(s string) *texttemplate.Template {
+		return texttemplate.Must(texttemplate.New("").Parse(s))
+	}
+	tests := []struct {
+		name  string
+		tmplA interface{}
+		tmplB interface{}
+		data  interface{}
+		want  string
+	}{
+		{
+			name:  "refactored html template",
+			tmplA: html(`<p class="a b">{{.}}</p>`),
+			tmplB: html(`{{define "p"}}<p class="b a">  {{.}}  </p>{{end}}{{template "p" .}}`),
+			data:  "hi",
+		},
+		{
+			name:  "differing html output",
+			tmplA: html(`<p>{{.}}</p>`),
+			tmplB: html(`<div>{{.}}</div>`),
+			data:  "hi",
+			want:  "HTML differs",
+		},
+		{
+			name:  "html escaping",
+			tmplA: html(`<p>{{.}}</p>`),
+			tmplB: html(`<p>&lt;b&gt;</p>`),
+			data:  "<b>",
+		},
+		{
+			name:  "refactored text template",
+			tmplA: text("{{range .}}{{.}}\n{{end}}"),
+			tmplB: text("{{range $v := .}}{{$v}}\n{{end}}"),
+			data:  []string{"a", "b"},
+		},
+		{
+			name:  "differing text output",
+			tmplA: text("{{.}}\n"),
+			tmplB: text("{{.}}!\n"),
+			data:  "hi",
+			want:  "Strings differ\n-hi\n+hi!",
+		},
+		{
+			name:  "text templates compared exactly",
+			tmplA: text("<p>{{.}}</p>"),
+			tmplB: text("<p> {{.}} </p>"),
+			data:  "hi",
+			want:  "Strings differ",
+		},
+		{
+			name:  "first template fails",
+			tmplA: text("{{.Foo}}"),
+			tmplB: text("{{.}}"),
+			data:  "hi",
+			want:  "Error executing first template: ",
+		},
+		{
+			name:  "second template fails",
+			tmplA: html("{{.}}"),
+			tmplB: html(`{{template "missing"}}`),
+			data:  "hi",
+			want:  "Error executing second template: ",
+		},
+		{
+			name:  "unsupported type",
+			tmplA: "{{.}}",
+			tmplB: text("{{.}}"),
+			want:  "Error executing first template: unsupported template type string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := TemplatesEquivalent(m, tt.tmplA, tt.tmplB, tt.data)
+			if got != (tt.want == "") {
+				t.Fatalf("TemplatesEquivalent returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
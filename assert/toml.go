@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOMLEqual asserts that the expected and actual TOML documents are
+// semantically equivalent, ignoring key order, formatting and comments. On
+// failure, a diff of the documents re-serialized in canonical form is shown.
+func TOMLEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var e, a map[string]interface{}
+	if _, err := toml.Decode(expected, &e); err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected TOML: %s", err), msgAndArgs...)
+	}
+	if _, err := toml.Decode(actual, &a); err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing actual TOML: %s", err), msgAndArgs...)
+	}
+	if reflect.DeepEqual(e, a) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "TOML documents differ", canonicalTOML(e), canonicalTOML(a), o, msgAndArgs...)
+}
+
+// TOMLEqual asserts that the expected and actual TOML documents are
+// semantically equivalent, ignoring key order, formatting and comments. On
+// failure, a diff of the documents re-serialized in canonical form is shown.
+func (a *Assertions) TOMLEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return TOMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+func canonicalTOML(v map[string]interface{}) string {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(v); err != nil {
+		panic("Error re-encoding TOML: " + err.Error())
+	}
+	return buf.String()
+}
@@ -0,0 +1,149 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithUnorderedSlices causes DeepEqual, and the other assertions built on
+// DeepEqualWithOptions, to compare slices as multisets, ignoring the order of
+// their elements. If paths are given, such as "Items" or "Groups[*].Members",
+// only the slices at those paths are compared without regard to order;
+// otherwise all slices are. The failure diff shows only the missing and extra
+// elements of such slices.
+func WithUnorderedSlices(paths ...string) Option {
+	opts := Options{UnorderedSlices: len(paths) == 0, UnorderedPaths: paths}
+	return func(o *options) {
+		o.compare = o.compare.merge(opts)
+	}
+}
+
+// unordered reports whether the slice at path is compared without regard to
+// the order of its elements.
+func (o *Options) unordered(path string) bool {
+	if o.UnorderedSlices {
+		return true
+	}
+	if len(o.UnorderedPaths) == 0 {
+		return false
+	}
+	segments := splitPath(path)
+	for _, p := range o.UnorderedPaths {
+		if pathMatches(splitPath(p), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchElements matches each element of the slice e, at path, to an equal
+// element of the slice a, each of which may be matched only once. It returns
+// the index in a of the match for each element of e, or -1 if it has none.
+// As equality under tolerances is not transitive, the first equal element is
+// not necessarily the right match, so as many elements are matched as
+// possible.
+func matchElements(e, a reflect.Value, path string, opts *Options) []int {
+	return maxMatching(e.Len(), a.Len(), func(i, j int) bool {
+		c := newComparer(opts)
+		c.compare(e.Index(i), a.Index(j), fmt.Sprintf("%s[%d]", path, i))
+		return len(c.diffs) == 0
+	})
+}
+
+// maxMatching pairs as many as possible of n expected elements with distinct
+// ones of m actual elements, for which match reports true, by finding
+// augmenting paths. It returns the index of the actual element paired with
+// each expected one, or -1 if it has none. Each pair is tested at most once.
+func maxMatching(n, m int, match func(i, j int) bool) []int {
+	matches := make([][]bool, n)
+	for i := range matches {
+		matches[i] = make([]bool, m)
+		for j := range matches[i] {
+			matches[i][j] = match(i, j)
+		}
+	}
+	// owner[j] is the index of the expected element matched to actual
+	// element j, or -1.
+	owner := make([]int, m)
+	for j := range owner {
+		owner[j] = -1
+	}
+	var assign func(i int, seen []bool) bool
+	assign = func(i int, seen []bool) bool {
+		for j := range owner {
+			if !matches[i][j] || seen[j] {
+				continue
+			}
+			seen[j] = true
+			if owner[j] < 0 || assign(owner[j], seen) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < n; i++ {
+		assign(i, make([]bool, m))
+	}
+	result := make([]int, n)
+	for i := range result {
+		result[i] = -1
+	}
+	for j, i := range owner {
+		if i >= 0 {
+			result[i] = j
+		}
+	}
+	return result
+}
+
+func (c *comparer) compareUnordered(e, a reflect.Value, path string) {
+	matches := matchElements(e, a, path, c.opts)
+	used := make([]bool, a.Len())
+	for i, j := range matches {
+		if j < 0 {
+			c.differ(fmt.Sprintf("%s[%d]", path, i), e.Index(i), reflect.Value{})
+			continue
+		}
+		used[j] = true
+	}
+	for j, u := range used {
+		if !u {
+			c.differ(fmt.Sprintf("%s[%d]", path, j), reflect.Value{}, a.Index(j))
+		}
+	}
+}
+
+// unorderedSeq returns the indexes of the elements of the slice v, and of
+// the corresponding elements of other, in the order in which they are to be
+// dumped: the matched elements, in the order of the expected slice, followed
+// by the unmatched elements. An index of -1 means no corresponding element.
+func (d *dumper) unorderedSeq(v, other reflect.Value) (order, others []int) {
+	e, a := v, other
+	if d.actual {
+		e, a = other, v
+	}
+	matches := matchElements(e, a, d.path, d.compare)
+	var eOrder, aOrder []int
+	used := make([]bool, a.Len())
+	for i, j := range matches {
+		if j >= 0 {
+			eOrder, aOrder = append(eOrder, i), append(aOrder, j)
+			used[j] = true
+		}
+	}
+	if !d.actual {
+		for i, j := range matches {
+			if j < 0 {
+				eOrder, aOrder = append(eOrder, i), append(aOrder, -1)
+			}
+		}
+		return eOrder, aOrder
+	}
+	for j, u := range used {
+		if !u {
+			aOrder, eOrder = append(aOrder, j), append(eOrder, -1)
+		}
+	}
+	return aOrder, eOrder
+}
@@ -0,0 +1,77 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaxMatching(t *testing.T) {
+	tests := []struct {
+		name  string
+		match [][]bool
+		want  []int
+	}{
+		{
+			name: "empty",
+			want: []int{},
+		},
+		{
+			name:  "identity",
+			match: [][]bool{{true, false}, {false, true}},
+			want:  []int{0, 1},
+		},
+		{
+			name:  "first fit is wrong",
+			match: [][]bool{{true, true}, {true, false}},
+			want:  []int{1, 0},
+		},
+		{
+			name:  "augmenting path of length three",
+			match: [][]bool{{true, true, false}, {true, false, false}, {false, true, true}},
+			want:  []int{1, 0, 2},
+		},
+		{
+			name:  "unmatched",
+			match: [][]bool{{true, false}, {true, false}},
+			want:  []int{0, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m int
+			if len(tt.match) > 0 {
+				m = len(tt.match[0])
+			}
+			got := maxMatching(len(tt.match), m, func(i, j int) bool {
+				return tt.match[i][j]
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnorderedWithTolerance(t *testing.T) {
+	// With a tolerance, 1.1 is equal to both 1.0 and 1.2, so matching it to
+	// the first equal element, 1.0, leaves 1.0 unmatched.
+	expected := []float64{1.1, 1.0}
+	actual := []float64{1.0, 1.2}
+	opts := []interface{}{WithFloatTolerance(0.15)}
+
+	if !DeepEqual(t, expected, actual, append(opts, WithUnorderedSlices())...) {
+		t.Error("DeepEqual with WithUnorderedSlices failed")
+	}
+	if !ElementsMatchDiff(t, expected, actual, opts...) {
+		t.Error("ElementsMatchDiff failed")
+	}
+
+	m := &mockT{}
+	if DeepEqual(m, expected, []float64{1.0, 1.5}, append(opts, WithUnorderedSlices())...) {
+		t.Error("DeepEqual with WithUnorderedSlices passed unequal slices")
+	}
+	m = &mockT{}
+	if ElementsMatchDiff(m, expected, []float64{1.0, 1.5}, opts...) {
+		t.Error("ElementsMatchDiff passed unequal slices")
+	}
+}
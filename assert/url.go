@@ -0,0 +1,218 @@
+package assert
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WithIgnoreTrailingSlash causes path comparisons to treat paths which differ
+// only by a trailing slash, such as /users and /users/, as equal.
+func WithIgnoreTrailingSlash() Option {
+	return func(o *options) {
+		o.ignoreTrailingSlash = true
+	}
+}
+
+// PathEqual asserts that the two URL paths are equal. Paths are compared
+// exactly, unless the WithIgnoreTrailingSlash option is passed.
+func PathEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	exp, act := normalizePath(expected, o), normalizePath(actual, o)
+	if exp == act {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Paths differ:\nexpected: %s\nactual  : %s", exp, act), msgAndArgs...)
+}
+
+// PathEqual asserts that the two URL paths are equal. Paths are compared
+// exactly, unless the WithIgnoreTrailingSlash option is passed.
+func (a *Assertions) PathEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PathEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// normalizePath applies the path normalization selected by o. When trailing
+// slashes are ignored, the empty path is considered equal to the root path.
+func normalizePath(path string, o *options) string {
+	if !o.ignoreTrailingSlash {
+		return path
+	}
+	if path = strings.TrimSuffix(path, "/"); path == "" {
+		return "/"
+	}
+	return path
+}
+
+// URLEqual asserts that the two URLs are equivalent. Both are parsed and
+// their parts compared individually: the scheme and host are compared
+// case-insensitively, and query parameters are treated as an unordered
+// multimap, so that neither the order of parameters nor that of repeated
+// values matters. The path honors the WithIgnoreTrailingSlash option. On
+// failure, a diff of the decomposed URLs is shown.
+func URLEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	exp, err := url.Parse(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected URL: %s", err), msgAndArgs...)
+	}
+	act, err := url.Parse(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing actual URL: %s", err), msgAndArgs...)
+	}
+	expParts, err := urlParts(exp, o)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected URL: %s", err), msgAndArgs...)
+	}
+	actParts, err := urlParts(act, o)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing actual URL: %s", err), msgAndArgs...)
+	}
+	if expParts == actParts {
+		return true
+	}
+	return failDiff(t, "URLs differ", expParts, actParts, o, msgAndArgs...)
+}
+
+// URLEqual asserts that the two URLs are equivalent. Both are parsed and
+// their parts compared individually: the scheme and host are compared
+// case-insensitively, and query parameters are treated as an unordered
+// multimap, so that neither the order of parameters nor that of repeated
+// values matters. The path honors the WithIgnoreTrailingSlash option. On
+// failure, a diff of the decomposed URLs is shown.
+func (a *Assertions) URLEqual(expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return URLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// QueryValuesEqual asserts that the two sets of query values are equal,
+// treating them as unordered multimaps. Accepts url.Values,
+// map[string][]string, or raw query strings, with or without a leading "?".
+// On failure, the missing, unexpected and mismatched keys are listed.
+func QueryValuesEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	exp, err := queryValues(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid expected query: %s", err), msgAndArgs...)
+	}
+	act, err := queryValues(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid actual query: %s", err), msgAndArgs...)
+	}
+	var problems []string
+	for _, key := range sortedQueryKeys(exp) {
+		actValues, ok := act[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("missing key %q: %q", key, exp[key]))
+			continue
+		}
+		e, a := sortedCopy(exp[key]), sortedCopy(actValues)
+		if !reflect.DeepEqual(e, a) {
+			problems = append(problems, fmt.Sprintf("key %q: expected %q, actual %q", key, e, a))
+		}
+	}
+	for _, key := range sortedQueryKeys(act) {
+		if _, ok := exp[key]; !ok {
+			problems = append(problems, fmt.Sprintf("unexpected key %q: %q", key, act[key]))
+		}
+	}
+	if len(problems) == 0 {
+		return true
+	}
+	return Fail(t, "Query values differ\n"+strings.Join(problems, "\n"), msgAndArgs...)
+}
+
+// QueryValuesEqual asserts that the two sets of query values are equal,
+// treating them as unordered multimaps. Accepts url.Values,
+// map[string][]string, or raw query strings, with or without a leading "?".
+// On failure, the missing, unexpected and mismatched keys are listed.
+func (a *Assertions) QueryValuesEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return QueryValuesEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+func queryValues(i interface{}) (url.Values, error) {
+	switch v := i.(type) {
+	case url.Values:
+		return v, nil
+	case map[string][]string:
+		return url.Values(v), nil
+	case string:
+		return url.ParseQuery(strings.TrimPrefix(v, "?"))
+	}
+	return nil, errors.Errorf("unknown type: %T", i)
+}
+
+func sortedQueryKeys(v url.Values) []string {
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCopy(values []string) []string {
+	c := append([]string(nil), values...)
+	sort.Strings(c)
+	return c
+}
+
+// urlParts renders the normalized parts of u, one per line. An error is
+// returned if the query is malformed.
+func urlParts(u *url.URL, o *options) (string, error) {
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return "", err
+	}
+	lines := []string{
+		"scheme: " + strings.ToLower(u.Scheme),
+		"host: " + strings.ToLower(u.Host),
+	}
+	if u.User != nil {
+		lines = append(lines, "user: "+u.User.String())
+	}
+	if u.Opaque != "" {
+		lines = append(lines, "opaque: "+u.Opaque)
+	} else {
+		lines = append(lines, "path: "+normalizePath(u.EscapedPath(), o))
+	}
+	for _, param := range queryLines(query) {
+		lines = append(lines, "query: "+param)
+	}
+	if u.Fragment != "" {
+		lines = append(lines, "fragment: "+u.EscapedFragment())
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// queryLines renders each value of v as an escaped key=value pair, sorted by
+// key and then by value.
+func queryLines(v url.Values) []string {
+	var lines []string
+	for key, values := range v {
+		for _, value := range values {
+			lines = append(lines, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
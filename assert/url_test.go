@@ -0,0 +1,144 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestURLEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		opts             []interface{}
+		want             string
+	}{
+		{
+			name:     "equal",
+			expected: "https://example.com/a?x=1&y=2",
+			actual:   "HTTPS://EXAMPLE.COM/a?y=2&x=1",
+		},
+		{
+			name:     "repeated values in any order",
+			expected: "https://example.com/?x=1&x=2",
+			actual:   "https://example.com/?x=2&x=1",
+		},
+		{
+			name:     "differing query",
+			expected: "https://example.com/?x=1",
+			actual:   "https://example.com/?x=2",
+			want:     "URLs differ",
+		},
+		{
+			name:     "malformed expected query",
+			expected: "https://example.com/?x=%zz",
+			actual:   "https://example.com/",
+			want:     "Error parsing expected URL",
+		},
+		{
+			name:     "malformed actual query",
+			expected: "https://example.com/?x=1",
+			actual:   "https://example.com/?x=1&y=%zz",
+			want:     "Error parsing actual URL",
+		},
+		{
+			name:     "trailing slash significant by default",
+			expected: "https://example.com/users?x=1",
+			actual:   "https://example.com/users/?x=1",
+			want:     "URLs differ\n-path: /users\n+path: /users/",
+		},
+		{
+			name:     "trailing slash ignored",
+			expected: "https://example.com/users?x=1",
+			actual:   "https://example.com/users/?x=1",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+		},
+		{
+			name:     "empty and root paths with trailing slash ignored",
+			expected: "https://example.com",
+			actual:   "https://example.com/",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+		},
+		{
+			name:     "differing paths with trailing slash ignored",
+			expected: "https://example.com/users/",
+			actual:   "https://example.com/groups/",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+			want:     "URLs differ\n-path: /users\n+path: /groups",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := URLEqual(m, tt.expected, tt.actual, tt.opts...)
+			if got != (tt.want == "") {
+				t.Fatalf("URLEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
+
+func TestPathEqual(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		opts             []interface{}
+		want             string
+	}{
+		{
+			name:     "equal",
+			expected: "/users",
+			actual:   "/users",
+		},
+		{
+			name:     "trailing slash significant by default",
+			expected: "/users",
+			actual:   "/users/",
+			want:     "Paths differ:\nexpected: /users\nactual  : /users/",
+		},
+		{
+			name:     "trailing slash ignored",
+			expected: "/users",
+			actual:   "/users/",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+		},
+		{
+			name:     "empty and root paths with trailing slash ignored",
+			expected: "/",
+			actual:   "",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+		},
+		{
+			name:     "only one trailing slash ignored",
+			expected: "/users",
+			actual:   "/users//",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+			want:     "Paths differ:\nexpected: /users\nactual  : /users/",
+		},
+		{
+			name:     "normalized forms reported",
+			expected: "/users/",
+			actual:   "/groups/",
+			opts:     []interface{}{WithIgnoreTrailingSlash()},
+			want:     "Paths differ:\nexpected: /users\nactual  : /groups",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockT{}
+			got := PathEqual(m, tt.expected, tt.actual, tt.opts...)
+			if got != (tt.want == "") {
+				t.Fatalf("PathEqual returned %t:\n%s", got, m.output())
+			}
+			for _, line := range strings.Split(tt.want, "\n") {
+				if !strings.Contains(m.output(), line) {
+					t.Errorf("failure does not contain %q:\n%s", line, m.output())
+				}
+			}
+		})
+	}
+}
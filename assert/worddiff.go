@@ -0,0 +1,211 @@
+package assert
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ANSI escape sequences used to highlight changed words within a line. These
+// do not reset the color of the surrounding line.
+const (
+	ansiReverse    = "\x1b[7m"
+	ansiReverseOff = "\x1b[27m"
+)
+
+// minWordDiffRatio is the minimum similarity of a removed and added line for
+// the words which differ between them to be highlighted. Below this, nearly
+// every word would be highlighted, which is no help.
+const minWordDiffRatio = 0.5
+
+// WithWordDiff causes the words which differ between a removed line and the
+// corresponding added line of a unified diff to be highlighted. When diffs
+// are colored, the changed words are shown in reverse video. Otherwise, each
+// such line is followed by a guide line, beginning with "?", with carets
+// beneath the changed words, in the style of Python's ndiff.
+func WithWordDiff() Option {
+	return func(o *options) {
+		o.wordDiff = true
+	}
+}
+
+// highlightWords highlights the changed words of each pair of removed and
+// added lines in the unified diff d.
+func highlightWords(d string, color bool) string {
+	lines := strings.SplitAfter(d, "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); {
+		if !isDiffLine(lines, i, '-') {
+			out = append(out, lines[i])
+			i++
+			continue
+		}
+		start := i
+		for i < len(lines) && isDiffLine(lines, i, '-') {
+			i++
+		}
+		removed := lines[start:i]
+		start = i
+		for i < len(lines) && isDiffLine(lines, i, '+') {
+			i++
+		}
+		added := lines[start:i]
+
+		removed, added = append([]string(nil), removed...), append([]string(nil), added...)
+		removedGuides, addedGuides := make([]string, len(removed)), make([]string, len(added))
+		for j := 0; j < len(removed) && j < len(added); j++ {
+			r, a, ok := wordDiff(removed[j], added[j], color)
+			switch {
+			case !ok:
+			case color:
+				removed[j], added[j] = r, a
+			default:
+				removedGuides[j], addedGuides[j] = r, a
+			}
+		}
+		out = appendGuided(out, removed, removedGuides)
+		out = appendGuided(out, added, addedGuides)
+	}
+	return strings.Join(out, "")
+}
+
+// isDiffLine reports whether lines[i] is a removed or added line, according
+// to prefix, of a unified diff, rather than a file header.
+func isDiffLine(lines []string, i int, prefix byte) bool {
+	line := lines[i]
+	if line == "" || line[0] != prefix {
+		return false
+	}
+	return !(i < 2 && (strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ")))
+}
+
+// appendGuided appends lines to out, each followed by its guide line, if any.
+func appendGuided(out, lines, guides []string) []string {
+	for i, line := range lines {
+		out = append(out, line)
+		if guides[i] != "" {
+			if !strings.HasSuffix(line, "\n") {
+				out[len(out)-1] += "\n"
+			}
+			out = append(out, guides[i])
+		}
+	}
+	return out
+}
+
+// wordDiff compares the removed and added diff lines r and a word by word. If
+// color is true, it returns the lines with the changed words highlighted;
+// otherwise it returns guide lines marking the changed words. ok is false if
+// the lines are too dissimilar for highlighting to be useful.
+func wordDiff(r, a string, color bool) (string, string, bool) {
+	rBody, rEnd := splitLineEnd(r[1:])
+	aBody, aEnd := splitLineEnd(a[1:])
+	rWords, aWords := splitWords(rBody), splitWords(aBody)
+	m := difflib.NewMatcher(rWords, aWords)
+	if m.Ratio() < minWordDiffRatio {
+		return "", "", false
+	}
+	codes := m.GetOpCodes()
+	var rChanged, aChanged []bool
+	for _, c := range codes {
+		for k := c.I1; k < c.I2; k++ {
+			rChanged = append(rChanged, c.Tag != 'e')
+		}
+		for k := c.J1; k < c.J2; k++ {
+			aChanged = append(aChanged, c.Tag != 'e')
+		}
+	}
+	if color {
+		return r[:1] + highlightedWords(rWords, rChanged) + rEnd, a[:1] + highlightedWords(aWords, aChanged) + aEnd, true
+	}
+	return guideLine(rWords, rChanged), guideLine(aWords, aChanged), true
+}
+
+// splitLineEnd splits s into its body and line terminator.
+func splitLineEnd(s string) (string, string) {
+	if strings.HasSuffix(s, "\n") {
+		return s[:len(s)-1], "\n"
+	}
+	return s, ""
+}
+
+// splitWords splits s into words, runs of whitespace, and individual
+// punctuation characters, which together make up s.
+func splitWords(s string) []string {
+	var words []string
+	class := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 0
+	}
+	start, prev := 0, -1
+	for i, r := range s {
+		c := class(r)
+		if i > start && (c != prev || c == 0) {
+			words = append(words, s[start:i])
+			start = i
+		}
+		prev = c
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// highlightedWords joins words, highlighting those which changed.
+func highlightedWords(words []string, changed []bool) string {
+	var b strings.Builder
+	for i, w := range words {
+		if changed[i] && (i == 0 || !changed[i-1]) {
+			b.WriteString(ansiReverse)
+		}
+		b.WriteString(w)
+		if changed[i] && (i == len(words)-1 || !changed[i+1]) {
+			b.WriteString(ansiReverseOff)
+		}
+	}
+	return b.String()
+}
+
+// guideLine returns a guide line with carets beneath the changed words, or
+// "" if no words changed. Tabs are preserved so that the carets line up with
+// the line above.
+func guideLine(words []string, changed []bool) string {
+	if !containsTrue(changed) {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteRune('?')
+	for i, w := range words {
+		switch {
+		case changed[i]:
+			b.WriteString(strings.Repeat("^", utf8.RuneCountInString(w)))
+		case strings.Contains(w, "\t"):
+			b.WriteString(strings.Map(func(r rune) rune {
+				if r == '\t' {
+					return r
+				}
+				return ' '
+			}, w))
+		default:
+			b.WriteString(strings.Repeat(" ", utf8.RuneCountInString(w)))
+		}
+	}
+	return strings.TrimRight(b.String(), " \t") + "\n"
+}
+
+func containsTrue(bs []bool) bool {
+	for _, b := range bs {
+		if b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// XMLEqual asserts that the two arguments represent equivalent XML documents.
+// Accepts strings, byte arrays, or io.Readers. Whitespace between elements and
+// surrounding text, attribute order, namespace prefixes, comments and
+// processing instructions are ignored. On failure, a diff of the normalized
+// documents is shown.
+func XMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expDoc, err := parseXML(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid expected document: %s", err), msgAndArgs...)
+	}
+	actDoc, err := parseXML(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("invalid actual document: %s", err), msgAndArgs...)
+	}
+	expString, actString := expDoc.String(), actDoc.String()
+	if expString == actString {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "XML differs", expString, actString, o, msgAndArgs...)
+}
+
+// XMLEqual asserts that the two arguments represent equivalent XML documents.
+// Accepts strings, byte arrays, or io.Readers. Whitespace between elements and
+// surrounding text, attribute order, namespace prefixes, comments and
+// processing instructions are ignored. On failure, a diff of the normalized
+// documents is shown.
+func (a *Assertions) XMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return XMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// xmlNode is an element or text node of a normalized XML document.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+}
+
+// xmlReader returns a reader for the XML document i, which may be a string,
+// a []byte, or an io.Reader.
+func xmlReader(i interface{}) (io.Reader, error) {
+	switch v := i.(type) {
+	case string:
+		return strings.NewReader(v), nil
+	case []byte:
+		return bytes.NewReader(v), nil
+	case io.Reader:
+		return v, nil
+	}
+	return nil, errors.Errorf("unknown type: %T", i)
+}
+
+func parseXML(i interface{}) (*xmlNode, error) {
+	r, err := xmlReader(i)
+	if err != nil {
+		return nil, err
+	}
+	dec := xml.NewDecoder(r)
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			parent.trimText()
+			n := &xmlNode{name: tok.Name}
+			for _, attr := range tok.Attr {
+				// Namespace declarations are reflected in the resolved names.
+				if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					continue
+				}
+				n.attrs = append(n.attrs, attr)
+			}
+			sort.Slice(n.attrs, func(i, j int) bool {
+				if n.attrs[i].Name.Space != n.attrs[j].Name.Space {
+					return n.attrs[i].Name.Space < n.attrs[j].Name.Space
+				}
+				return n.attrs[i].Name.Local < n.attrs[j].Name.Local
+			})
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			parent.trimText()
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			// Adjacent text, such as that either side of a comment, is merged
+			// and trimmed once the run of text ends.
+			if last := len(parent.children) - 1; last >= 0 && parent.children[last].name.Local == "" {
+				parent.children[last].text += string(tok)
+				continue
+			}
+			parent.children = append(parent.children, &xmlNode{text: string(tok)})
+		}
+	}
+	root.trimText()
+	if len(root.children) == 0 {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+// trimText trims the surrounding whitespace from n's last child, if it is
+// text, removing it when nothing remains.
+func (n *xmlNode) trimText() {
+	last := len(n.children) - 1
+	if last < 0 || n.children[last].name.Local != "" {
+		return
+	}
+	if text := strings.TrimSpace(n.children[last].text); text != "" {
+		n.children[last].text = text
+		return
+	}
+	n.children = n.children[:last]
+}
+
+func (n *xmlNode) String() string {
+	buf := &bytes.Buffer{}
+	for _, c := range n.children {
+		c.write(buf, 0)
+	}
+	return buf.String()
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+func xmlEscape(s string) string {
+	buf := &bytes.Buffer{}
+	xml.EscapeText(buf, []byte(s))
+	return buf.String()
+}
+
+func (n *xmlNode) write(buf *bytes.Buffer, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if n.name.Local == "" {
+		fmt.Fprintf(buf, "%s%s\n", indent, xmlEscape(n.text))
+		return
+	}
+	name := xmlName(n.name)
+	buf.WriteString(indent + "<" + name)
+	for _, attr := range n.attrs {
+		fmt.Fprintf(buf, " %s=\"%s\"", xmlName(attr.Name), xmlEscape(attr.Value))
+	}
+	switch {
+	case len(n.children) == 0:
+		buf.WriteString("/>\n")
+	case len(n.children) == 1 && n.children[0].name.Local == "":
+		fmt.Fprintf(buf, ">%s</%s>\n", xmlEscape(n.children[0].text), name)
+	default:
+		buf.WriteString(">\n")
+		for _, c := range n.children {
+			c.write(buf, depth+1)
+		}
+		fmt.Fprintf(buf, "%s</%s>\n", indent, name)
+	}
+}
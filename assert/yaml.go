@@ -0,0 +1,90 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLEqual asserts that expected and actual represent equivalent YAML
+// documents. Strings and byte arrays are parsed as YAML, and may contain
+// several documents, which are compared in order; any other value is first
+// marshaled to YAML. On failure, a diff of the canonical YAML
+// representations, with sorted keys, is shown.
+func YAMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	e, err := yamlDocuments(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing expected YAML: %s", err), msgAndArgs...)
+	}
+	a, err := yamlDocuments(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Error parsing actual YAML: %s", err), msgAndArgs...)
+	}
+	if reflect.DeepEqual(e, a) {
+		return true
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	return failDiff(t, "YAML representations differ", canonicalYAML(e), canonicalYAML(a), o, msgAndArgs...)
+}
+
+// YAMLEqual asserts that expected and actual represent equivalent YAML
+// documents. Strings and byte arrays are parsed as YAML, and may contain
+// several documents, which are compared in order; any other value is first
+// marshaled to YAML. On failure, a diff of the canonical YAML
+// representations, with sorted keys, is shown.
+func (a *Assertions) YAMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return YAMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// yamlDocuments returns each YAML document in i as a generic value, as
+// produced by yaml.Unmarshal.
+func yamlDocuments(i interface{}) ([]interface{}, error) {
+	var data []byte
+	switch v := i.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = yaml.Marshal(i); err != nil {
+			return nil, err
+		}
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var docs []interface{}
+	for {
+		var value interface{}
+		err := dec.Decode(&value)
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, value)
+	}
+}
+
+// canonicalYAML renders docs as a YAML stream, with sorted keys.
+func canonicalYAML(docs []interface{}) string {
+	out := make([]string, len(docs))
+	for i, v := range docs {
+		doc, err := yaml.Marshal(v)
+		if err != nil {
+			panic("Error re-marshaling YAML: " + err.Error())
+		}
+		out[i] = string(doc)
+	}
+	return strings.Join(out, "---\n")
+}
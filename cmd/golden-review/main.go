@@ -0,0 +1,154 @@
+// Command golden-review reviews the failed golden comparisons recorded by the
+// assertions of the golden package, when the tests are run with the
+// TESTIFY_GOLDEN_STATE environment variable set to a state directory. The
+// diff of each failure is shown in turn, and the actual output may then be
+// accepted, replacing the golden file, or rejected.
+//
+// Usage:
+//
+//	golden-review [-state dir] [-list]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flimzy/testify/golden"
+)
+
+func main() {
+	state := flag.String("state", os.Getenv(golden.StateEnv), "the state directory in which failures were recorded")
+	list := flag.Bool("list", false, "list the recorded failures, without reviewing them")
+	flag.Parse()
+	if *state == "" {
+		fmt.Fprintf(os.Stderr, "golden-review: no state directory; use -state or set %s\n", golden.StateEnv)
+		os.Exit(2)
+	}
+	if err := run(*state, *list, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "golden-review: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(state string, list bool, in io.Reader, out io.Writer) error {
+	failures, err := golden.Failures(state)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		fmt.Fprintln(out, "No failed golden comparisons to review.")
+		return nil
+	}
+	if list {
+		for _, f := range failures {
+			fmt.Fprintf(out, "%s\t%s\n", f.Test, f.Golden)
+		}
+		return nil
+	}
+	r := bufio.NewReader(in)
+	var accepted, rejected, skipped int
+	for i, f := range failures {
+		fmt.Fprintf(out, "\n[%d/%d] %s\n%s\n\n", i+1, len(failures), f.Test, f.Golden)
+		if err := show(out, f); err != nil {
+			return err
+		}
+		answer, err := prompt(r, out)
+		if err != nil {
+			return err
+		}
+		switch answer {
+		case 'y':
+			if err := f.Accept(); err != nil {
+				return err
+			}
+			accepted++
+		case 'n':
+			if err := f.Reject(); err != nil {
+				return err
+			}
+			rejected++
+		case 's':
+			skipped++
+		case 'q':
+			skipped += len(failures) - i
+			fmt.Fprintf(out, "\nAccepted %d, rejected %d, skipped %d.\n", accepted, rejected, skipped)
+			return nil
+		}
+	}
+	fmt.Fprintf(out, "\nAccepted %d, rejected %d, skipped %d.\n", accepted, rejected, skipped)
+	return nil
+}
+
+// prompt asks whether to accept the actual output, until a valid answer is
+// given. The end of the input is taken as quit.
+func prompt(r *bufio.Reader, out io.Writer) (byte, error) {
+	for {
+		fmt.Fprint(out, "Accept the actual output? [y]es, [n]o, [s]kip, [q]uit: ")
+		line, err := r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if answer != "" && strings.Contains("ynsq", answer[:1]) {
+			return answer[0], nil
+		}
+		if err == io.EOF {
+			fmt.Fprintln(out)
+			return 'q', nil
+		}
+	}
+}
+
+// show writes the diff of f, or, if the golden file did not exist, the actual
+// output which would become the golden file.
+func show(out io.Writer, f *golden.Failure) error {
+	if f.Diff != "" {
+		fmt.Fprint(out, f.Diff)
+		if !strings.HasSuffix(f.Diff, "\n") {
+			fmt.Fprintln(out)
+		}
+		return nil
+	}
+	if f.Dir {
+		fmt.Fprintln(out, "New golden directory, containing:")
+		return filepath.Walk(f.Actual(), func(path string, info os.FileInfo, err error) error {
+			if err != nil || path == f.Actual() {
+				return err
+			}
+			rel, err := filepath.Rel(f.Actual(), path)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if info.IsDir() {
+				rel += "/"
+			}
+			fmt.Fprintf(out, "+ %s\n", rel)
+			return nil
+		})
+	}
+	data, err := ioutil.ReadFile(f.Actual())
+	if err != nil {
+		return err
+	}
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		fmt.Fprintf(out, "New golden file, of %d bytes of binary data.\n", len(data))
+		return nil
+	}
+	fmt.Fprintln(out, "New golden file, containing:")
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(string(data), "\n"), "\n") {
+		fmt.Fprintf(out, "+%s", line)
+		if !strings.HasSuffix(line, "\n") {
+			fmt.Fprintln(out)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package golden
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// hexRowSize is the number of bytes shown on each row of a hexdump diff.
+	hexRowSize = 16
+	// hexContext is the number of unchanged rows shown around each differing
+	// row of a hexdump diff.
+	hexContext = 1
+	// maxHexRows is the greatest number of differing rows shown in a hexdump
+	// diff.
+	maxHexRows = 16
+)
+
+// isBinary reports whether data is to be treated as binary, rather than text,
+// because it contains NUL bytes or invalid UTF-8.
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data)
+}
+
+// firstDifference returns the offset of the first byte at which want and got
+// differ, or the length of the shorter if one is a prefix of the other.
+func firstDifference(want, got []byte) int {
+	for i := 0; i < len(want) && i < len(got); i++ {
+		if want[i] != got[i] {
+			return i
+		}
+	}
+	if len(want) < len(got) {
+		return len(want)
+	}
+	return len(got)
+}
+
+// hexDiff returns a side-by-side hexdump of the rows of want and got which
+// differ, with hexContext rows of context around each. Differing rows are
+// marked with "!". Rows beyond the first maxHexRows differing rows are
+// summarized.
+func hexDiff(want, got []byte) string {
+	rows := (maxLen(want, got) + hexRowSize - 1) / hexRowSize
+	var differing []int
+	for r := 0; r < rows; r++ {
+		if !bytes.Equal(hexRow(want, r), hexRow(got, r)) {
+			differing = append(differing, r)
+		}
+	}
+	omitted := 0
+	if len(differing) > maxHexRows {
+		omitted = len(differing) - maxHexRows
+		differing = differing[:maxHexRows]
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "  %-8s  %-*s  %s\n", "offset", 3*hexRowSize+hexRowSize+2, "expected", "actual")
+	last := -1
+	for _, d := range differing {
+		start, end := d-hexContext, d+hexContext
+		if start <= last {
+			start = last + 1
+		}
+		if start < 0 {
+			start = 0
+		}
+		if end >= rows {
+			end = rows - 1
+		}
+		if last >= 0 && start > last+1 {
+			buf.WriteString("  ...\n")
+		}
+		for r := start; r <= end; r++ {
+			marker := ' '
+			if !bytes.Equal(hexRow(want, r), hexRow(got, r)) {
+				marker = '!'
+			}
+			fmt.Fprintf(buf, "%c %08x  %s  %s\n", marker, r*hexRowSize, formatHexRow(hexRow(want, r)), formatHexRow(hexRow(got, r)))
+		}
+		last = end
+	}
+	if omitted > 0 {
+		fmt.Fprintf(buf, "… %d more differing rows\n", omitted)
+	}
+	return buf.String()
+}
+
+// hexRow returns row r of data.
+func hexRow(data []byte, r int) []byte {
+	start, end := r*hexRowSize, (r+1)*hexRowSize
+	if start > len(data) {
+		return nil
+	}
+	if end > len(data) {
+		end = len(data)
+	}
+	return data[start:end]
+}
+
+// formatHexRow formats row as hex bytes followed by their printable ASCII
+// characters, padded to the width of a full row.
+func formatHexRow(row []byte) string {
+	hex := make([]string, hexRowSize)
+	ascii := make([]byte, len(row))
+	for i := range hex {
+		hex[i] = "  "
+		if i < len(row) {
+			hex[i] = fmt.Sprintf("%02x", row[i])
+		}
+	}
+	for i, b := range row {
+		ascii[i] = '.'
+		if b >= 0x20 && b < 0x7f {
+			ascii[i] = b
+		}
+	}
+	return fmt.Sprintf("%s |%-*s|", strings.Join(hex, " "), hexRowSize, ascii)
+}
+
+func maxLen(a, b []byte) int {
+	if len(a) > len(b) {
+		return len(a)
+	}
+	return len(b)
+}
@@ -0,0 +1,223 @@
+package golden
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/flimzy/testify/assert"
+	"github.com/pkg/errors"
+)
+
+// entry is a file or directory within a tree compared by AssertDir.
+type entry struct {
+	path string
+	mode os.FileMode
+}
+
+// AssertDir asserts that the directory tree gotDir matches the golden tree
+// wantDir, which, unlike the names passed to the other assertions of this
+// package, is a path such as "testdata/want_dir". Both trees must contain the
+// same files and directories, and each file must have the same contents. With
+// WithFileModes, their permission bits must also match. On failure, the files
+// missing from or unexpected in gotDir are listed, followed by a diff of each
+// differing file. When the tests are run with -update, wantDir is instead
+// replaced with a copy of gotDir.
+func AssertDir(t TestingT, gotDir, wantDir string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+
+	got, err := readTree(gotDir)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Error reading directory: %s", err), msgAndArgs...)
+	}
+	if Update() {
+		if err := writeTree(wantDir, gotDir, got, o.modes); err != nil {
+			return assert.Fail(t, fmt.Sprintf("Error updating golden directory: %s", err), msgAndArgs...)
+		}
+		return true
+	}
+	want, err := readTree(wantDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			recordDir(t, wantDir, gotDir, got, o.modes, "")
+			return assert.Fail(t, fmt.Sprintf("Golden directory %s does not exist; run the tests with -update or TESTIFY_UPDATE=1 to create it", wantDir), msgAndArgs...)
+		}
+		return assert.Fail(t, fmt.Sprintf("Error reading golden directory: %s", err), msgAndArgs...)
+	}
+
+	gotEntries := make(map[string]entry, len(got))
+	for _, e := range got {
+		gotEntries[e.path] = e
+	}
+	var missing, unexpected, differing []string
+	for _, w := range want {
+		g, ok := gotEntries[w.path]
+		delete(gotEntries, w.path)
+		switch {
+		case !ok:
+			missing = append(missing, "  "+w.describe())
+		case w.mode.IsDir() != g.mode.IsDir():
+			differing = append(differing, fmt.Sprintf("  %s: expected %s, actual %s", w.path, kind(w.mode), kind(g.mode)))
+		default:
+			d, err := entryDiff(w, g, wantDir, gotDir, o, msgAndArgs)
+			if err != nil {
+				return assert.Fail(t, fmt.Sprintf("Error reading file: %s", err), msgAndArgs...)
+			}
+			if d != "" {
+				differing = append(differing, d)
+			}
+		}
+	}
+	for _, g := range got {
+		if _, ok := gotEntries[g.path]; ok {
+			unexpected = append(unexpected, "  "+g.describe())
+		}
+	}
+	if len(missing)+len(unexpected)+len(differing) == 0 {
+		clearRecord(wantDir)
+		return true
+	}
+	var report []string
+	for _, section := range []struct {
+		heading string
+		entries []string
+	}{
+		{"missing", missing},
+		{"unexpected", unexpected},
+		{"differing", differing},
+	} {
+		if len(section.entries) > 0 {
+			report = append(report, fmt.Sprintf("%s (%d):\n%s", section.heading, len(section.entries), strings.Join(section.entries, "\n")))
+		}
+	}
+	recordDir(t, wantDir, gotDir, got, o.modes, strings.Join(report, "\n")+"\n")
+	msg := fmt.Sprintf("Directory %s differs from golden directory %s", gotDir, wantDir)
+	return assert.Fail(t, msg+"\n"+strings.Join(report, "\n"), msgAndArgs...)
+}
+
+// entryDiff returns a description of the differences between the golden entry
+// w and the actual entry g, of the same kind, or "" if they do not differ.
+func entryDiff(w, g entry, wantDir, gotDir string, o *options, msgAndArgs []interface{}) (string, error) {
+	var diffs []string
+	if o.modes && w.mode.Perm() != g.mode.Perm() {
+		diffs = append(diffs, fmt.Sprintf("mode: expected %s, actual %s", w.mode.Perm(), g.mode.Perm()))
+	}
+	if !w.mode.IsDir() {
+		want, err := ioutil.ReadFile(filepath.Join(wantDir, filepath.FromSlash(w.path)))
+		if err != nil {
+			return "", err
+		}
+		got, err := ioutil.ReadFile(filepath.Join(gotDir, filepath.FromSlash(g.path)))
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case string(want) == string(got):
+		case isBinary(want) || isBinary(got):
+			diffs = append(diffs, fmt.Sprintf("binary contents differ at offset %d (expected %d bytes, actual %d bytes):\n%s",
+				firstDifference(want, got), len(want), len(got), indentLines(hexDiff(want, got), "  ")))
+		default:
+			diffs = append(diffs, indentLines(assert.Diff(string(want), string(got), assertOptions(msgAndArgs)...), "  "))
+		}
+	}
+	if len(diffs) == 0 {
+		return "", nil
+	}
+	return indentLines(w.path+":\n"+indentLines(strings.Join(diffs, "\n"), "  "), "  "), nil
+}
+
+// describe returns the path of e, with a trailing slash if it is a directory.
+func (e entry) describe() string {
+	if e.mode.IsDir() {
+		return e.path + "/"
+	}
+	return e.path
+}
+
+func kind(mode os.FileMode) string {
+	if mode.IsDir() {
+		return "directory"
+	}
+	return "file"
+}
+
+// readTree returns the files and directories beneath root, sorted by path,
+// which is relative to root and slash-separated. Only regular files and
+// directories are supported.
+func readTree(root string) ([]entry, error) {
+	var entries []entry
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			if !info.IsDir() {
+				return errors.Errorf("%s is not a directory", root)
+			}
+			return nil
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return errors.Errorf("%s is not a regular file or directory", path)
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{path: filepath.ToSlash(rel), mode: info.Mode()})
+		return nil
+	})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+	return entries, err
+}
+
+// writeTree replaces the golden tree wantDir with a copy of entries, read
+// from gotDir. The permission bits of each entry are copied if modes is true.
+func writeTree(wantDir, gotDir string, entries []entry, modes bool) error {
+	if err := os.RemoveAll(wantDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(wantDir, 0755); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		dst := filepath.Join(wantDir, filepath.FromSlash(e.path))
+		if e.mode.IsDir() {
+			if err := os.MkdirAll(dst, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(gotDir, filepath.FromSlash(e.path)))
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(dst, data, 0644); err != nil {
+			return err
+		}
+	}
+	if !modes {
+		return nil
+	}
+	// Permissions are set last, deepest first, so that read-only
+	// directories do not prevent their contents from being written.
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if err := os.Chmod(filepath.Join(wantDir, filepath.FromSlash(e.path)), e.mode.Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indentLines prefixes each line of s with prefix, removing any trailing
+// newline.
+func indentLines(s, prefix string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix)
+}
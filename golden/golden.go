@@ -0,0 +1,150 @@
+// Package golden provides assertions which compare test output against golden
+// files, stored beneath the testdata directory of the package being tested.
+// When the tests are run with the -update flag, or with the TESTIFY_UPDATE
+// environment variable set to 1, the golden files are instead rewritten with
+// the actual output, and the assertions pass. The flag is defined by testify
+// itself, so the package being tested must not define one of its own.
+//
+// Rather than updating every golden file blindly, the failed comparisons may
+// be recorded, by setting the TESTIFY_GOLDEN_STATE environment variable to a
+// directory, and then reviewed one by one, with the golden-review command:
+//
+//	TESTIFY_GOLDEN_STATE=$PWD/.golden go test ./...
+//	go run github.com/flimzy/testify/cmd/golden-review -state .golden
+package golden
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flimzy/testify/assert"
+	"github.com/flimzy/testify/internal/update"
+)
+
+// TestingT is an interface wrapper around *testing.T
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+	Name() string
+}
+
+// tHelper is implemented by *testing.T. If a TestingT also implements it,
+// assertions mark themselves as test helpers, so that failures are attributed
+// to the calling test.
+type tHelper interface {
+	Helper()
+}
+
+// Dir is the directory, relative to the package being tested, in which golden
+// files are stored.
+const Dir = "testdata"
+
+// UpdateEnv is the environment variable which, when set to a true value such
+// as 1, causes golden files to be rewritten rather than compared.
+const UpdateEnv = update.Env
+
+// Update reports whether golden files are to be rewritten rather than
+// compared: if the tests are run with the -update flag, or with
+// TESTIFY_UPDATE set to a true value.
+func Update() bool {
+	return update.Enabled()
+}
+
+// Path returns the path of the golden file with the given name.
+func Path(name string) string {
+	return filepath.Join(Dir, name)
+}
+
+// Assert asserts that actual, a string or []byte, matches the contents of the
+// golden file for the test, beneath testdata. The file is named after the
+// test, so that each subtest of a table-driven test has a golden file of its
+// own, such as testdata/TestRender_empty_input.golden, unless a name is given
+// with WithName. On failure, a diff is shown, which may be configured with
+// the diff options of the assert package, such as assert.WithDiffContext,
+// passed in msgAndArgs. If either side is binary, containing NUL bytes or
+// invalid UTF-8, a side-by-side hexdump of the rows which differ is shown
+// instead. When the tests are run with -update, the golden file is instead
+// written with actual.
+//
+// Assert formerly took the name of the golden file as its third argument, as
+// in Assert(t, got, "foo.golden"); such calls should be changed to
+// Assert(t, got, WithName("foo.golden")). So that they do not silently begin
+// to use "foo.golden" as the failure message, a leading string argument
+// ending in .golden is still taken to be the name of the golden file.
+func Assert(t TestingT, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	if len(msgAndArgs) > 0 {
+		if name, ok := msgAndArgs[0].(string); ok && strings.HasSuffix(name, ".golden") {
+			o.name, msgAndArgs = name, msgAndArgs[1:]
+		}
+	}
+	var got []byte
+	switch a := actual.(type) {
+	case []byte:
+		got = a
+	case string:
+		got = []byte(a)
+	default:
+		return assert.Fail(t, fmt.Sprintf("Unsupported golden value type: %T", actual), msgAndArgs...)
+	}
+	return compare(t, Path(goldenName(t, o, ".golden")), got, nil, msgAndArgs...)
+}
+
+// compare compares got to the golden file at path, or updates the golden
+// file, if running with -update. If normalize is not nil, it is applied to
+// the contents of the golden file before they are compared; got must already
+// be normalized.
+func compare(t TestingT, path string, got []byte, normalize func([]byte) ([]byte, error), msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if Update() {
+		if err := update.Write(path, got); err != nil {
+			return assert.Fail(t, fmt.Sprintf("Error updating golden file: %s", err), msgAndArgs...)
+		}
+		return true
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			recordFile(t, path, got, "")
+			return assert.Fail(t, fmt.Sprintf("Golden file %s does not exist; run the tests with -update or TESTIFY_UPDATE=1 to create it", path), msgAndArgs...)
+		}
+		return assert.Fail(t, fmt.Sprintf("Error reading golden file: %s", err), msgAndArgs...)
+	}
+	if normalize != nil {
+		if want, err = normalize(want); err != nil {
+			return assert.Fail(t, fmt.Sprintf("Invalid golden file %s: %s", path, err), msgAndArgs...)
+		}
+	}
+	if string(want) == string(got) {
+		clearRecord(path)
+		return true
+	}
+	if isBinary(want) || isBinary(got) {
+		diff := hexDiff(want, got)
+		recordFile(t, path, got, diff)
+		return assert.FailDiff(t, fmt.Sprintf("Output differs from binary golden file %s at offset %d (expected %d bytes, actual %d bytes)",
+			path, firstDifference(want, got), len(want), len(got)), diff, msgAndArgs...)
+	}
+	diff := assert.Diff(string(want), string(got), assertOptions(msgAndArgs)...)
+	recordFile(t, path, got, diff)
+	return assert.FailDiff(t, fmt.Sprintf("Output differs from golden file %s", path), diff, msgAndArgs...)
+}
+
+// assertOptions returns the assert.Options in msgAndArgs.
+func assertOptions(msgAndArgs []interface{}) []assert.Option {
+	var opts []assert.Option
+	for _, arg := range msgAndArgs {
+		if opt, ok := arg.(assert.Option); ok {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
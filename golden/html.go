@@ -0,0 +1,32 @@
+package golden
+
+import (
+	"fmt"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// AssertHTML asserts that actual, a string, []byte, *html.Node, or goquery
+// selection, is equivalent HTML to the golden file for the test, beneath
+// testdata, named as by Assert, but with the extension .html. Both sides are
+// normalized as by assert.HTMLEqual before they are compared, so that
+// insignificant differences of markup, such as attribute order or
+// indentation, do not cause failures. Comments may be ignored with
+// assert.WithIgnoreHTMLComments. When the tests are run with -update, the
+// golden file is written with actual in this normalized form.
+func AssertHTML(t TestingT, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	opts := assertOptions(msgAndArgs)
+	got, err := assert.NormalizeHTML(actual, opts...)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Invalid actual document: %s", err), msgAndArgs...)
+	}
+	normalize := func(data []byte) ([]byte, error) {
+		doc, err := assert.NormalizeHTML(data, opts...)
+		return []byte(doc), err
+	}
+	return compare(t, Path(goldenName(t, o, ".html")), []byte(got), normalize, msgAndArgs...)
+}
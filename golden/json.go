@@ -0,0 +1,66 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flimzy/testify/assert"
+	"github.com/pkg/errors"
+)
+
+// AssertJSON asserts that actual is equivalent JSON to the golden file for
+// the test, beneath testdata, named as by Assert, but with the extension
+// .json. actual may be a string, []byte, or json.RawMessage containing JSON,
+// or any other value, which is marshaled to JSON. Both sides are re-indented,
+// with object keys sorted, before they are compared, so that changes to the
+// formatting of the golden file do not cause failures. When the tests are run
+// with -update, the golden file is written with actual in this canonical
+// form.
+func AssertJSON(t TestingT, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	var data []byte
+	switch a := actual.(type) {
+	case []byte:
+		data = a
+	case json.RawMessage:
+		data = a
+	case string:
+		data = []byte(a)
+	default:
+		var err error
+		if data, err = json.Marshal(actual); err != nil {
+			return assert.Fail(t, fmt.Sprintf("Error marshaling actual value: %s", err), msgAndArgs...)
+		}
+	}
+	got, err := canonicalJSON(data)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Actual value is not valid JSON: %s", err), msgAndArgs...)
+	}
+	return compare(t, Path(goldenName(t, o, ".json")), got, canonicalJSON, msgAndArgs...)
+}
+
+// canonicalJSON returns data re-indented, with object keys sorted, and with
+// numbers preserved as written.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package golden
+
+import (
+	"regexp"
+)
+
+// Option configures the assertions of this package. Options may be passed
+// among msgAndArgs, along with any assert.Options.
+type Option func(*options)
+
+type options struct {
+	name  string
+	modes bool
+}
+
+// WithName sets the name of the golden file, beneath testdata, used by
+// Assert, AssertJSON, or AssertHTML, in place of the name derived from the
+// name of the test.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithFileModes causes AssertDir to compare the permission bits of files and
+// directories, as well as their names and contents, and to preserve them when
+// updating the golden tree.
+func WithFileModes() Option {
+	return func(o *options) {
+		o.modes = true
+	}
+}
+
+// parseOptions returns the Options in msgAndArgs, applied, and the remaining
+// arguments.
+func parseOptions(msgAndArgs []interface{}) (*options, []interface{}) {
+	o := &options{}
+	args := make([]interface{}, 0, len(msgAndArgs))
+	for _, arg := range msgAndArgs {
+		if opt, ok := arg.(Option); ok {
+			opt(o)
+			continue
+		}
+		args = append(args, arg)
+	}
+	return o, args
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// goldenName returns the name of the golden file used by t: the name given by
+// WithName, or else the name of the test, including any subtests, with each
+// run of characters unsafe in file names, such as the slashes separating
+// subtests, replaced by an underscore, followed by ext.
+func goldenName(t TestingT, o *options, ext string) string {
+	if o.name != "" {
+		return o.name
+	}
+	return unsafeFileChars.ReplaceAllString(t.Name(), "_") + ext
+}
@@ -0,0 +1,197 @@
+package golden
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/flimzy/testify/internal/update"
+)
+
+// StateEnv is the environment variable which, when set to a directory, causes
+// each failed golden comparison to be recorded there, along with the actual
+// output, so that it may be reviewed, and accepted or rejected, by the
+// golden-review command.
+const StateEnv = "TESTIFY_GOLDEN_STATE"
+
+const (
+	recordFileName = "failure.json"
+	actualFileName = "actual"
+)
+
+// Failure is a failed golden comparison recorded in a state directory.
+type Failure struct {
+	// Test is the name of the test which failed.
+	Test string `json:"test"`
+	// Golden is the absolute path of the golden file, or directory.
+	Golden string `json:"golden"`
+	// Dir is true if Golden is a directory, compared by AssertDir.
+	Dir bool `json:"dir,omitempty"`
+	// Modes is true if the permission bits of the files of a golden
+	// directory are significant, and so are to be preserved on update.
+	Modes bool `json:"modes,omitempty"`
+	// Diff is the diff, or list of differences, reported by the failure. It
+	// is empty if the golden file did not exist.
+	Diff string `json:"diff,omitempty"`
+
+	// dir is the directory in which the failure is recorded.
+	dir string
+}
+
+// Actual returns the path of the actual output, a file or directory according
+// to f.Dir, recorded for the failure.
+func (f *Failure) Actual() string {
+	return filepath.Join(f.dir, actualFileName)
+}
+
+// Accept replaces the golden file, or directory, with the actual output, and
+// removes the failure from the state directory.
+func (f *Failure) Accept() error {
+	if f.Dir {
+		entries, err := readTree(f.Actual())
+		if err != nil {
+			return err
+		}
+		if err := writeTree(f.Golden, f.Actual(), entries, f.Modes); err != nil {
+			return err
+		}
+		return f.Reject()
+	}
+	data, err := ioutil.ReadFile(f.Actual())
+	if err != nil {
+		return err
+	}
+	if err := update.Write(f.Golden, data); err != nil {
+		return err
+	}
+	return f.Reject()
+}
+
+// Reject removes the failure from the state directory, leaving the golden
+// file unchanged.
+func (f *Failure) Reject() error {
+	return os.RemoveAll(f.dir)
+}
+
+// Failures returns the failures recorded in the state directory stateDir,
+// ordered by test name and then golden path.
+func Failures(stateDir string) ([]*Failure, error) {
+	dirs, err := ioutil.ReadDir(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	var failures []*Failure
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		dir := filepath.Join(stateDir, d.Name())
+		data, err := ioutil.ReadFile(filepath.Join(dir, recordFileName))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		f := &Failure{dir: dir}
+		if err := json.Unmarshal(data, f); err != nil {
+			return nil, err
+		}
+		failures = append(failures, f)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Test != failures[j].Test {
+			return failures[i].Test < failures[j].Test
+		}
+		return failures[i].Golden < failures[j].Golden
+	})
+	return failures, nil
+}
+
+// recordFile records, in the state directory, if any, the failed comparison
+// of got to the golden file at path. Errors recording the failure are
+// ignored, so as not to obscure the failure itself.
+func recordFile(t TestingT, path string, got []byte, diff string) {
+	dir, f := newRecord(t, path, diff)
+	if dir == "" {
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, actualFileName), got, 0644); err != nil {
+		return
+	}
+	saveRecord(dir, f)
+}
+
+// recordDir records, in the state directory, if any, the failed comparison of
+// the tree gotDir, containing entries, to the golden directory path.
+func recordDir(t TestingT, path, gotDir string, entries []entry, modes bool, diff string) {
+	dir, f := newRecord(t, path, diff)
+	if dir == "" {
+		return
+	}
+	f.Dir, f.Modes = true, modes
+	if err := writeTree(filepath.Join(dir, actualFileName), gotDir, entries, true); err != nil {
+		return
+	}
+	saveRecord(dir, f)
+}
+
+// newRecord creates an empty directory in which to record a failure of the
+// golden file path, replacing any earlier failure of that file, and returns
+// it, along with the Failure to be saved there once the actual output has
+// been written. It returns "" if there is no state directory.
+func newRecord(t TestingT, path, diff string) (string, *Failure) {
+	dir := recordPath(path)
+	if dir == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return "", nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil
+	}
+	return dir, &Failure{Test: t.Name(), Golden: abs, Diff: diff}
+}
+
+func saveRecord(dir string, f *Failure) {
+	data, err := json.MarshalIndent(f, "", "    ")
+	if err != nil {
+		return
+	}
+	ioutil.WriteFile(filepath.Join(dir, recordFileName), data, 0644)
+}
+
+// clearRecord removes any recorded failure of the golden file path, which has
+// since matched.
+func clearRecord(path string) {
+	if dir := recordPath(path); dir != "" {
+		os.RemoveAll(dir)
+	}
+}
+
+// recordPath returns the directory, within the state directory, in which
+// failures of the golden file path are recorded, or "" if there is no state
+// directory. The name of the directory is derived from the absolute path of
+// the golden file, so that only the latest failure of each is kept.
+func recordPath(path string) string {
+	state := os.Getenv(StateEnv)
+	if state == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha1.Sum([]byte(abs))
+	name := unsafeFileChars.ReplaceAllString(filepath.Base(abs), "_")
+	return filepath.Join(state, name+"-"+hex.EncodeToString(sum[:8]))
+}
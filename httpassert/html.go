@@ -0,0 +1,26 @@
+package httpassert
+
+import (
+	"github.com/flimzy/testify/assert"
+)
+
+// HTMLBody asserts that the body of the response resp, a *http.Response or
+// *httptest.ResponseRecorder, is equivalent HTML to expected, a string,
+// []byte, *html.Node, or goquery selection, as by assert.HTMLEqual. With
+// WithSelector, only the elements matched by the selector are compared, so
+// that expected need only contain the part of the page of interest. The body
+// is restored after it is read, so that it may be read again.
+func HTMLBody(t TestingT, resp interface{}, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	o, msgAndArgs := parseOptions(msgAndArgs)
+	body, err := readBody(resp)
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+	if o.selector != "" {
+		return assert.HTMLSelectionEqual(t, o.selector, expected, body, msgAndArgs...)
+	}
+	return assert.HTMLEqual(t, expected, body, msgAndArgs...)
+}
@@ -0,0 +1,227 @@
+// Package httpassert provides assertions on HTTP responses, given as
+// *http.Response or *httptest.ResponseRecorder, for use in the tests of HTTP
+// handlers and clients.
+package httpassert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/flimzy/testify/assert"
+	"github.com/pkg/errors"
+)
+
+// TestingT is an interface wrapper around *testing.T
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+// tHelper is implemented by *testing.T. If a TestingT also implements it,
+// assertions mark themselves as test helpers, so that failures are attributed
+// to the calling test.
+type tHelper interface {
+	Helper()
+}
+
+// A Comparator compares the expected body of a response to the actual body,
+// reporting any failure to t. Text, JSON and HTML are provided.
+type Comparator func(t TestingT, expected interface{}, actual []byte, msgAndArgs ...interface{}) bool
+
+var (
+	// Text compares the body, line by line, to the expected string or
+	// []byte, as by assert.LinesEqual.
+	Text Comparator = compareText
+	// JSON compares the body to the expected value, as by
+	// assert.DeepEqualJSON. An expected string, []byte or json.RawMessage is
+	// taken to contain JSON; any other value is marshaled to JSON.
+	JSON Comparator = compareJSON
+	// HTML compares the body to the expected document, as by
+	// assert.HTMLEqual.
+	HTML Comparator = compareHTML
+)
+
+func compareText(t TestingT, expected interface{}, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	switch e := expected.(type) {
+	case string:
+		return assert.LinesEqual(t, e, string(actual), msgAndArgs...)
+	case []byte:
+		return assert.LinesEqual(t, string(e), string(actual), msgAndArgs...)
+	}
+	return assert.Fail(t, fmt.Sprintf("Unsupported expected body type: %T", expected), msgAndArgs...)
+}
+
+func compareJSON(t TestingT, expected interface{}, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	switch e := expected.(type) {
+	case string:
+		expected = json.RawMessage(e)
+	case []byte:
+		expected = json.RawMessage(e)
+	}
+	if !json.Valid(actual) {
+		return assert.Fail(t, fmt.Sprintf("Response body is not valid JSON:\n%s", actual), msgAndArgs...)
+	}
+	return assert.DeepEqualJSON(t, expected, json.RawMessage(actual), msgAndArgs...)
+}
+
+func compareHTML(t TestingT, expected interface{}, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return assert.HTMLEqual(t, expected, actual, msgAndArgs...)
+}
+
+// response returns resp, a *http.Response or *httptest.ResponseRecorder, as a
+// *http.Response.
+func response(resp interface{}) (*http.Response, error) {
+	switch r := resp.(type) {
+	case *http.Response:
+		if r == nil {
+			return nil, errors.New("response is nil")
+		}
+		return r, nil
+	case *httptest.ResponseRecorder:
+		if r == nil {
+			return nil, errors.New("response recorder is nil")
+		}
+		return r.Result(), nil
+	}
+	return nil, errors.Errorf("unsupported response type: %T", resp)
+}
+
+// readBody returns the body of resp, a *http.Response or
+// *httptest.ResponseRecorder. The body of a *http.Response is restored after
+// it is read, so that it may be read again.
+func readBody(resp interface{}) ([]byte, error) {
+	if rr, ok := resp.(*httptest.ResponseRecorder); ok && rr != nil {
+		if rr.Body == nil {
+			return nil, nil
+		}
+		return rr.Body.Bytes(), nil
+	}
+	r, err := response(resp)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body")
+	}
+	return body, nil
+}
+
+// StatusCode asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, has the expected status code.
+func StatusCode(t TestingT, resp interface{}, expected int, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	r, err := response(resp)
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+	if r.StatusCode == expected {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("Expected status %d %s, got %d %s",
+		expected, http.StatusText(expected), r.StatusCode, http.StatusText(r.StatusCode)), msgAndArgs...)
+}
+
+// HasHeader asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, has the header key, with any value.
+func HasHeader(t TestingT, resp interface{}, key string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	r, err := response(resp)
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+	if _, ok := r.Header[http.CanonicalHeaderKey(key)]; ok {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("Response has no %s header", http.CanonicalHeaderKey(key)), msgAndArgs...)
+}
+
+// HeaderEqual asserts that the header key of the response resp, a
+// *http.Response or *httptest.ResponseRecorder, has exactly the expected
+// values, in order.
+func HeaderEqual(t TestingT, resp interface{}, key string, expected []string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	r, err := response(resp)
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+	key = http.CanonicalHeaderKey(key)
+	actual := r.Header[key]
+	if valuesEqual(expected, actual) {
+		return true
+	}
+	return assert.FailDiff(t, fmt.Sprintf("%s header differs", key),
+		assert.Diff(formatValues(key, expected), formatValues(key, actual), assertOptions(msgAndArgs)...), msgAndArgs...)
+}
+
+func valuesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatValues renders the values of the header key, one per line, as they
+// would appear in a response.
+func formatValues(key string, values []string) string {
+	buf := &bytes.Buffer{}
+	for _, v := range values {
+		fmt.Fprintf(buf, "%s: %s\n", key, v)
+	}
+	return buf.String()
+}
+
+// Body asserts that the body of the response resp, a *http.Response or
+// *httptest.ResponseRecorder, matches expected, according to compare, such as
+// Text, JSON or HTML. The body is restored after it is read, so that it may be
+// read again.
+func Body(t TestingT, resp interface{}, expected interface{}, compare Comparator, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+	return compare(t, expected, body, msgAndArgs...)
+}
+
+// assertOptions returns the assert.Options in msgAndArgs.
+func assertOptions(msgAndArgs []interface{}) []assert.Option {
+	var opts []assert.Option
+	for _, arg := range msgAndArgs {
+		if opt, ok := arg.(assert.Option); ok {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
@@ -0,0 +1,40 @@
+package httpassert
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// JSONBody asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, has a JSON Content-Type, such as
+// application/json or application/problem+json, and a body which is
+// equivalent JSON to expected, as by assert.DeepEqualJSON. An expected string,
+// []byte or json.RawMessage is taken to contain JSON; any other value is
+// marshaled to JSON. The body is restored after it is read, so that it may be
+// read again.
+func JSONBody(t TestingT, resp interface{}, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	r, err := response(resp)
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+	if contentType := r.Header.Get("Content-Type"); !isJSONMediaType(contentType) {
+		return assert.Fail(t, fmt.Sprintf("Expected a JSON Content-Type, got %q", contentType), msgAndArgs...)
+	}
+	return Body(t, resp, expected, JSON, msgAndArgs...)
+}
+
+// isJSONMediaType reports whether contentType is application/json, or a
+// media type with the +json structured syntax suffix.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
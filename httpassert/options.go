@@ -0,0 +1,33 @@
+package httpassert
+
+// Option configures the assertions of this package. Options may be passed
+// among msgAndArgs, along with any assert.Options.
+type Option func(*options)
+
+type options struct {
+	selector string
+}
+
+// WithSelector causes HTMLBody to compare only the elements matched by the
+// CSS selector, in both the expected document and the response body, as by
+// assert.HTMLSelectionEqual.
+func WithSelector(selector string) Option {
+	return func(o *options) {
+		o.selector = selector
+	}
+}
+
+// parseOptions returns the Options in msgAndArgs, applied, and the remaining
+// arguments.
+func parseOptions(msgAndArgs []interface{}) (*options, []interface{}) {
+	o := &options{}
+	args := make([]interface{}, 0, len(msgAndArgs))
+	for _, arg := range msgAndArgs {
+		if opt, ok := arg.(Option); ok {
+			opt(o)
+			continue
+		}
+		args = append(args, arg)
+	}
+	return o, args
+}
@@ -0,0 +1,54 @@
+// Package update decides whether golden files are to be rewritten rather than
+// compared, and rewrites them. It is shared by the assert, golden and recorder
+// packages, so that the -update flag is defined, and read, in one place.
+package update
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Env is the environment variable which, when set to a true value such as 1,
+// causes golden files to be rewritten rather than compared.
+const Env = "TESTIFY_UPDATE"
+
+// init defines the -update flag, unless a package initialized earlier has
+// already done so. A test package which imports testify must not define an
+// -update flag of its own, as the flag package would then panic; it may use
+// golden.Update instead.
+func init() {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update golden files instead of comparing against them")
+	}
+}
+
+// Enabled reports whether golden files are to be rewritten rather than
+// compared: if the tests are run with the -update flag, or with TESTIFY_UPDATE
+// set to a true value.
+func Enabled() bool {
+	if update, err := strconv.ParseBool(os.Getenv(Env)); err == nil && update {
+		return true
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	update, _ := getter.Get().(bool)
+	return update
+}
+
+// Write writes data to the golden file at path, creating any missing parent
+// directories.
+func Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
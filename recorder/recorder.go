@@ -0,0 +1,350 @@
+// Package recorder provides an http.RoundTripper which records the requests
+// made through it, and the responses to them, to a cassette beneath
+// testdata, and replays the recorded responses in later runs, so that the
+// tests of HTTP clients need not depend on the servers they talk to.
+//
+// A cassette is recorded the first time the tests are run, or whenever golden
+// files are updated, with the -update flag or with TESTIFY_UPDATE=1, as
+// reported by golden.Update; otherwise it is replayed:
+//
+//	func TestClient(t *testing.T) {
+//		r := recorder.New(t, "client")
+//		defer r.Stop()
+//		client := r.Client()
+//		...
+//	}
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/flimzy/testify/assert"
+	"github.com/flimzy/testify/golden"
+	"github.com/pkg/errors"
+)
+
+// TestingT is an interface wrapper around *testing.T
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+// tHelper is implemented by *testing.T. If a TestingT also implements it,
+// assertions mark themselves as test helpers, so that failures are attributed
+// to the calling test.
+type tHelper interface {
+	Helper()
+}
+
+// Dir is the directory, relative to the package being tested, in which
+// cassettes are stored.
+const Dir = "testdata/cassettes"
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// Auto records if the cassette does not exist, or if golden.Update
+	// reports that golden files are being updated, and otherwise replays.
+	Auto Mode = iota
+	// Record always makes real requests, and records them.
+	Record
+	// Replay always replays the cassette, failing if it does not exist.
+	Replay
+)
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithMode sets the mode of the Recorder. The default is Auto.
+func WithMode(mode Mode) Option {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithTransport sets the transport through which real requests are made when
+// recording. The default is http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithIgnoredHeaders causes the given request and response headers to be
+// neither recorded nor compared, in addition to the Authorization and Cookie
+// request headers and the Set-Cookie response header, which never are, so
+// that credentials and session cookies are not written to cassettes.
+func WithIgnoredHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		for _, h := range headers {
+			r.ignoredHeaders[http.CanonicalHeaderKey(h)] = true
+		}
+	}
+}
+
+// WithRequestAssertions causes a replaying Recorder to expect the requests to
+// be made in the order in which they were recorded, and to report a diff of
+// each request which differs from its recording, in method, URL, headers, or
+// body. Stop then also reports any recorded requests which were not made. By
+// default, each request is matched to the first unused recording of the same
+// method and URL, and is otherwise unchecked.
+func WithRequestAssertions() Option {
+	return func(r *Recorder) {
+		r.assertRequests = true
+	}
+}
+
+// Recorder is an http.RoundTripper which records or replays a cassette.
+type Recorder struct {
+	t              TestingT
+	path           string
+	mode           Mode
+	transport      http.RoundTripper
+	ignoredHeaders map[string]bool
+	assertRequests bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	next     int
+}
+
+var _ http.RoundTripper = &Recorder{}
+
+// New returns a Recorder for the cassette with the given name, stored in
+// testdata/cassettes/<name>.json. Failures, such as requests which were not
+// recorded, are reported to t. Stop must be called once the requests have
+// been made, to save a recorded cassette.
+func New(t TestingT, name string, opts ...Option) *Recorder {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	r := &Recorder{
+		t:              t,
+		path:           filepath.Join(Dir, name+".json"),
+		transport:      http.DefaultTransport,
+		ignoredHeaders: map[string]bool{"Authorization": true, "Cookie": true, "Set-Cookie": true},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.mode == Auto {
+		r.mode = Replay
+		if _, err := os.Stat(r.path); os.IsNotExist(err) || golden.Update() {
+			r.mode = Record
+		}
+	}
+	if r.mode == Record {
+		r.cassette = &Cassette{}
+		return r
+	}
+	c, err := loadCassette(r.path)
+	if err != nil {
+		assert.Fail(t, fmt.Sprintf("Error loading cassette: %s", err))
+		c = &Cassette{}
+	}
+	r.cassette = c
+	r.used = make([]bool, len(c.Interactions))
+	return r
+}
+
+// Client returns an http.Client which makes its requests through r.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Mode returns the mode in which r is operating, Record or Replay.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper. When recording, the request is made
+// through the real transport, and the response recorded. When replaying, the
+// recorded response to the request is returned.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		// RoundTrip must not modify the request, so the restored body is
+		// given to a copy.
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	recorded := r.request(req, body)
+	if r.mode == Record {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Header:     r.header(resp.Header),
+			Body:       body,
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.match(recorded)
+	if i < 0 {
+		assert.Fail(r.t, fmt.Sprintf("No recorded response to %s %s in cassette %s", recorded.Method, recorded.URL, r.path))
+		return nil, errors.Errorf("no recorded response to %s %s", recorded.Method, recorded.URL)
+	}
+	r.used[i] = true
+	interaction := r.cassette.Interactions[i]
+	if r.assertRequests {
+		want, got := formatRequest(interaction.Request), formatRequest(recorded)
+		if want != got {
+			assert.FailDiff(r.t, fmt.Sprintf("Request %d differs from its recording in cassette %s", i+1, r.path), assert.Diff(want, got))
+		}
+	}
+	resp := interaction.Response
+	header := http.Header(resp.Header).Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        resp.Status,
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// match returns the index of the interaction to replay in response to req,
+// or -1 if there is none.
+func (r *Recorder) match(req Request) int {
+	if r.assertRequests {
+		if r.next >= len(r.cassette.Interactions) {
+			return -1
+		}
+		r.next++
+		return r.next - 1
+	}
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] && interaction.Request.Method == req.Method && interaction.Request.URL == req.URL {
+			return i
+		}
+	}
+	return -1
+}
+
+// Stop saves the cassette, if recording. If replaying with
+// WithRequestAssertions, it asserts that every recorded request was made.
+func (r *Recorder) Stop() bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == Record {
+		if err := r.cassette.save(r.path); err != nil {
+			return assert.Fail(r.t, fmt.Sprintf("Error saving cassette: %s", err))
+		}
+		return true
+	}
+	if !r.assertRequests || r.next == len(r.cassette.Interactions) {
+		return true
+	}
+	var missing []string
+	for _, interaction := range r.cassette.Interactions[r.next:] {
+		missing = append(missing, fmt.Sprintf("  %s %s", interaction.Request.Method, interaction.Request.URL))
+	}
+	return assert.Fail(r.t, fmt.Sprintf("%d of the requests recorded in cassette %s were not made:\n%s",
+		len(missing), r.path, strings.Join(missing, "\n")))
+}
+
+// request returns the recording of req, with the given body.
+func (r *Recorder) request(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: r.header(req.Header),
+		Body:   body,
+	}
+}
+
+// header returns the recording of h, without the ignored headers.
+func (r *Recorder) header(h http.Header) map[string][]string {
+	if h == nil {
+		return nil
+	}
+	header := make(map[string][]string, len(h))
+	for k, v := range h {
+		if !r.ignoredHeaders[http.CanonicalHeaderKey(k)] {
+			header[k] = append([]string(nil), v...)
+		}
+	}
+	return header
+}
+
+// formatRequest renders req as it would appear on the wire, with headers
+// sorted, so that two requests may be diffed.
+func formatRequest(req Request) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s %s\n", req.Method, req.URL)
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range req.Header[k] {
+			fmt.Fprintf(buf, "%s: %s\n", k, v)
+		}
+	}
+	if len(req.Body) > 0 {
+		fmt.Fprintf(buf, "\n%s\n", req.Body)
+	}
+	return buf.String()
+}
+
+// readBody reads and closes the request or response body *body, which the
+// caller must replace if it is to be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading body")
+	}
+	return data, nil
+}
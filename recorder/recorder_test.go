@@ -0,0 +1,54 @@
+package recorder
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecordScrubsHeaders(t *testing.T) {
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Content-Type", "text/plain")
+		header.Set("Set-Cookie", "session=secret")
+		header.Set("X-Trace", "abc")
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("ok")),
+		}, nil
+	})
+	r := New(t, "scrub", WithMode(Record), WithTransport(transport), WithIgnoredHeaders("X-Trace"))
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("Accept", "text/plain")
+	req.Header.Set("X-Trace", "abc")
+	resp, err := r.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Header.Get("Set-Cookie"); got != "session=secret" {
+		t.Errorf("Set-Cookie was removed from the response: %q", got)
+	}
+
+	interaction := r.cassette.Interactions[0]
+	if want := map[string][]string{"Accept": {"text/plain"}}; !reflect.DeepEqual(interaction.Request.Header, want) {
+		t.Errorf("recorded request header %v, want %v", interaction.Request.Header, want)
+	}
+	if want := map[string][]string{"Content-Type": {"text/plain"}}; !reflect.DeepEqual(interaction.Response.Header, want) {
+		t.Errorf("recorded response header %v, want %v", interaction.Response.Header, want)
+	}
+}
@@ -0,0 +1,37 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// HTMLAccessibilityTreeEqual asserts that the two arguments have equivalent
+// accessibility trees. Accepts strings, byte arrays, *html.Node objects, or
+// goquery selection. A simplified accessibility tree is extracted from each
+// document, consisting of the role, accessible name and ARIA attributes of
+// each element which has a role, explicit or implicit, along with any text.
+// Elements without a role, such as <div> and <span>, and hidden elements do
+// not appear in the tree, so purely presentational differences in markup are
+// ignored. On failure, the first differing node is reported by its path of
+// roles and names.
+func HTMLAccessibilityTreeEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLAccessibilityTreeEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLAccessibilityTreeEqual asserts that the two arguments have equivalent
+// accessibility trees. Accepts strings, byte arrays, *html.Node objects, or
+// goquery selection. A simplified accessibility tree is extracted from each
+// document, consisting of the role, accessible name and ARIA attributes of
+// each element which has a role, explicit or implicit, along with any text.
+// Elements without a role, such as <div> and <span>, and hidden elements do
+// not appear in the tree, so purely presentational differences in markup are
+// ignored. On failure, the first differing node is reported by its path of
+// roles and names.
+func (a *Assertions) HTMLAccessibilityTreeEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLAccessibilityTreeEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// EqualAny asserts that actual is deeply equal to at least one of the
+// candidates. This is useful when output is nondeterministic, but limited to
+// a known set of valid forms. On failure, a diff against the closest
+// candidate, as measured by the number of differing leaf values, is shown.
+// The comparison options accepted by DeepEqual also apply.
+func EqualAny(t TestingT, actual interface{}, candidates []interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.EqualAny(t, actual, candidates, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// EqualAny asserts that actual is deeply equal to at least one of the
+// candidates. This is useful when output is nondeterministic, but limited to
+// a known set of valid forms. On failure, a diff against the closest
+// candidate, as measured by the number of differing leaf values, is shown.
+// The comparison options accepted by DeepEqual also apply.
+func (a *Assertions) EqualAny(actual interface{}, candidates []interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EqualAny(a.t, actual, candidates, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,41 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// CanonicalXMLEqual asserts that the two arguments have identical W3C
+// Exclusive XML Canonicalization (without comments) forms. Accepts strings,
+// byte arrays, or io.Readers. Canonicalization discards the XML declaration,
+// DTD and comments, orders attributes, renders each namespace declaration
+// only on the outermost element which uses it, and normalizes quoting, empty
+// elements and character references, so that documents which differ only in
+// these respects are equal. Unlike XMLEqual, whitespace and namespace
+// prefixes are significant, as they are to an XML signature, which a change
+// of prefix invalidates; use XMLEqual where prefixes do not matter. On
+// failure, a diff of the canonical forms is shown, one tag or quoted text node
+// per line, noting if the documents would be equal to XMLEqual.
+func CanonicalXMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.CanonicalXMLEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// CanonicalXMLEqual asserts that the two arguments have identical W3C
+// Exclusive XML Canonicalization (without comments) forms. Accepts strings,
+// byte arrays, or io.Readers. Canonicalization discards the XML declaration,
+// DTD and comments, orders attributes, renders each namespace declaration
+// only on the outermost element which uses it, and normalizes quoting, empty
+// elements and character references, so that documents which differ only in
+// these respects are equal. Unlike XMLEqual, whitespace and namespace
+// prefixes are significant, as they are to an XML signature, which a change
+// of prefix invalidates; use XMLEqual where prefixes do not matter. On
+// failure, a diff of the canonical forms is shown, one tag or quoted text node
+// per line, noting if the documents would be equal to XMLEqual.
+func (a *Assertions) CanonicalXMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	CanonicalXMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,25 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// CBOREqual asserts that the two CBOR-encoded byte slices decode to deeply
+// equal values. On failure, a diff of the values in CBOR diagnostic
+// notation (RFC 8949, section 8) is shown.
+func CBOREqual(t TestingT, expected, actual []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.CBOREqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// CBOREqual asserts that the two CBOR-encoded byte slices decode to deeply
+// equal values. On failure, a diff of the values in CBOR diagnostic
+// notation (RFC 8949, section 8) is shown.
+func (a *Assertions) CBOREqual(expected, actual []byte, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	CBOREqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,25 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// DeepEqualCmp asserts that two objects are deeply equal, as determined by
+// github.com/google/go-cmp. It is equivalent to DeepEqual with the WithCmp
+// option, which may also be passed to provide cmp.Options.
+func DeepEqualCmp(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualCmp(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualCmp asserts that two objects are deeply equal, as determined by
+// github.com/google/go-cmp. It is equivalent to DeepEqual with the WithCmp
+// option, which may also be passed to provide cmp.Options.
+func (a *Assertions) DeepEqualCmp(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualCmp(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// DeepEqualWithOptions asserts that two objects are deeply equal, subject to
+// opts, which bundles ignore rules, tolerances for floats and times,
+// leniency between nil and empty collections, and custom comparers. Ignored
+// values are omitted from the failure diff, and values considered equal under
+// opts are rendered identically on both sides of it.
+func DeepEqualWithOptions(t TestingT, expected, actual interface{}, opts assert.Options, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualWithOptions(t, expected, actual, opts, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualWithOptions asserts that two objects are deeply equal, subject to
+// opts, which bundles ignore rules, tolerances for floats and times,
+// leniency between nil and empty collections, and custom comparers. Ignored
+// values are omitted from the failure diff, and values considered equal under
+// opts are rendered identically on both sides of it.
+func (a *Assertions) DeepEqualWithOptions(expected, actual interface{}, opts assert.Options, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualWithOptions(a.t, expected, actual, opts, a.withOptions(msgAndArgs)...)
+}
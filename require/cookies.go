@@ -0,0 +1,59 @@
+package require
+
+import (
+	"net/http"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// CookiesEqual asserts that the two slices contain equivalent cookies. Cookies
+// are matched by name, domain and path, without regard to order, and compared
+// on their Value, HttpOnly, Secure and SameSite fields. Volatile fields, such
+// as Expires and MaxAge, are ignored.
+func CookiesEqual(t TestingT, expected, actual []*http.Cookie, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.CookiesEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// CookiesEqual asserts that the two slices contain equivalent cookies. Cookies
+// are matched by name, domain and path, without regard to order, and compared
+// on their Value, HttpOnly, Secure and SameSite fields. Volatile fields, such
+// as Expires and MaxAge, are ignored.
+func (a *Assertions) CookiesEqual(expected, actual []*http.Cookie, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	CookiesEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// CookieEqual asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, sets the cookie name, with a Set-Cookie header,
+// equivalent to expected. The cookies are compared on their Value, Path,
+// Domain, Expires, MaxAge, Secure, HttpOnly and SameSite fields. Expires and
+// MaxAge may differ by up to the tolerance given with WithTimeTolerance. On
+// failure, a field-by-field diff is shown.
+func CookieEqual(t TestingT, resp interface{}, name string, expected *http.Cookie, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.CookieEqual(t, resp, name, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// CookieEqual asserts that the response resp, a *http.Response or
+// *httptest.ResponseRecorder, sets the cookie name, with a Set-Cookie header,
+// equivalent to expected. The cookies are compared on their Value, Path,
+// Domain, Expires, MaxAge, Secure, HttpOnly and SameSite fields. Expires and
+// MaxAge may differ by up to the tolerance given with WithTimeTolerance. On
+// failure, a field-by-field diff is shown.
+func (a *Assertions) CookieEqual(resp interface{}, name string, expected *http.Cookie, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	CookieEqual(a.t, resp, name, expected, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// CSVEqual asserts that the two CSV documents are equal, comparing them row
+// by row and cell by cell. On failure, the row and column coordinates of the
+// first differences are reported, followed by a diff of the documents. The
+// WithCSVHeader and WithUnorderedCSVColumns options may be passed to make the
+// comparison aware of a header row.
+func CSVEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.CSVEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// CSVEqual asserts that the two CSV documents are equal, comparing them row
+// by row and cell by cell. On failure, the row and column coordinates of the
+// first differences are reported, followed by a diff of the documents. The
+// WithCSVHeader and WithUnorderedCSVColumns options may be passed to make the
+// comparison aware of a header row.
+func (a *Assertions) CSVEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	CSVEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,45 @@
+package require
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// DecimalEqual asserts that the two decimals represent the same value,
+// regardless of their internal exponents, so that 1.0 and 1.00 are equal.
+func DecimalEqual(t TestingT, expected, actual decimal.Decimal, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DecimalEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DecimalEqual asserts that the two decimals represent the same value,
+// regardless of their internal exponents, so that 1.0 and 1.00 are equal.
+func (a *Assertions) DecimalEqual(expected, actual decimal.Decimal, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DecimalEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// DecimalInDelta asserts that the two decimals differ by no more than delta.
+func DecimalInDelta(t TestingT, expected, actual, delta decimal.Decimal, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DecimalInDelta(t, expected, actual, delta, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DecimalInDelta asserts that the two decimals differ by no more than delta.
+func (a *Assertions) DecimalInDelta(expected, actual, delta decimal.Decimal, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DecimalInDelta(a.t, expected, actual, delta, a.withOptions(msgAndArgs)...)
+}
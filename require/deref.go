@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// DeepEqualDeref asserts that two objects are deeply equal, after
+// dereferencing any pointers, at any depth, whether held in maps, slices or
+// struct fields. This allows a map[string]*T to be compared to an equivalent
+// map[string]T. A nil pointer is treated as the zero value of the type it
+// points to, and a map entry holding a nil pointer is also treated as an
+// absent key. On failure, the path to the first difference is reported.
+func DeepEqualDeref(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualDeref(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualDeref asserts that two objects are deeply equal, after
+// dereferencing any pointers, at any depth, whether held in maps, slices or
+// struct fields. This allows a map[string]*T to be compared to an equivalent
+// map[string]T. A nil pointer is treated as the zero value of the type it
+// points to, and a map entry holding a nil pointer is also treated as an
+// absent key. On failure, the path to the first difference is reported.
+func (a *Assertions) DeepEqualDeref(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualDeref(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// ElementsMatchDiff asserts that the expected and actual slices or arrays
+// contain the same elements, in any order, with each element matched only
+// once. On failure, it reports the elements only in expected, the elements
+// only in actual, and, for each remaining expected element which it can pair
+// with a similar actual element, a diff of the two. The comparison options
+// accepted by DeepEqual also apply to the elements.
+func ElementsMatchDiff(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.ElementsMatchDiff(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// ElementsMatchDiff asserts that the expected and actual slices or arrays
+// contain the same elements, in any order, with each element matched only
+// once. On failure, it reports the elements only in expected, the elements
+// only in actual, and, for each remaining expected element which it can pair
+// with a similar actual element, a diff of the two. The comparison options
+// accepted by DeepEqual also apply to the elements.
+func (a *Assertions) ElementsMatchDiff(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ElementsMatchDiff(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
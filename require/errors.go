@@ -0,0 +1,55 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// StructuredErrorEqual asserts that two errors are equal. When both errors
+// are, or wrap, a StructuredError, as found by errors.As, the codes, messages
+// and fields of those StructuredErrors are compared individually, and the
+// differing components are reported. Otherwise the errors are compared as
+// with DeepEqual.
+func StructuredErrorEqual(t TestingT, expected, actual error, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.StructuredErrorEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// StructuredErrorEqual asserts that two errors are equal. When both errors
+// are, or wrap, a StructuredError, as found by errors.As, the codes, messages
+// and fields of those StructuredErrors are compared individually, and the
+// differing components are reported. Otherwise the errors are compared as
+// with DeepEqual.
+func (a *Assertions) StructuredErrorEqual(expected, actual error, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	StructuredErrorEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// ErrorEqualIgnoringStack asserts that two errors have the same message
+// chain, ignoring any stack traces attached to them, such as by
+// github.com/pkg/errors. Each error is unwrapped via its Cause or Unwrap
+// method, and the message at each level is compared. On failure, a diff of
+// the two message chains is shown.
+func ErrorEqualIgnoringStack(t TestingT, expected, actual error, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.ErrorEqualIgnoringStack(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// ErrorEqualIgnoringStack asserts that two errors have the same message
+// chain, ignoring any stack traces attached to them, such as by
+// github.com/pkg/errors. Each error is unwrapped via its Cause or Unwrap
+// method, and the message at each level is compared. On failure, a diff of
+// the two message chains is shown.
+func (a *Assertions) ErrorEqualIgnoringStack(expected, actual error, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ErrorEqualIgnoringStack(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,31 @@
+package require
+
+import (
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// FieldMaskEqual asserts that the two field masks cover the same fields. Both
+// masks are normalized before comparison, so path order, duplicate paths, and
+// paths already covered by a parent path (such as "a.b" alongside "a") are not
+// significant. On failure, the paths present in only one mask are reported.
+func FieldMaskEqual(t TestingT, expected, actual *fieldmaskpb.FieldMask, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.FieldMaskEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// FieldMaskEqual asserts that the two field masks cover the same fields. Both
+// masks are normalized before comparison, so path order, duplicate paths, and
+// paths already covered by a parent path (such as "a.b" alongside "a") are not
+// significant. On failure, the paths present in only one mask are reported.
+func (a *Assertions) FieldMaskEqual(expected, actual *fieldmaskpb.FieldMask, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	FieldMaskEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
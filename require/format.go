@@ -0,0 +1,27 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// FormatsTo asserts that formatting value with the given verb, as
+// fmt.Sprintf(verb, value), produces the expected string. This exercises any
+// Format, GoString, String or Error method of value, with verbs such as %v,
+// %+v, %#v and %s.
+func FormatsTo(t TestingT, verb, expected string, value interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.FormatsTo(t, verb, expected, value, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// FormatsTo asserts that formatting value with the given verb, as
+// fmt.Sprintf(verb, value), produces the expected string. This exercises any
+// Format, GoString, String or Error method of value, with verbs such as %v,
+// %+v, %#v and %s.
+func (a *Assertions) FormatsTo(verb, expected string, value interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	FormatsTo(a.t, verb, expected, value, a.withOptions(msgAndArgs)...)
+}
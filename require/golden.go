@@ -0,0 +1,27 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// MarshalsToJSONFile asserts that the actual interface{} marshals to JSON
+// equivalent to the contents of the file at path. When the tests are run with
+// the -update flag, or with TESTIFY_UPDATE=1, the file is instead rewritten
+// with the indented JSON representation of actual, and the assertion passes.
+func MarshalsToJSONFile(t TestingT, path string, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.MarshalsToJSONFile(t, path, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// MarshalsToJSONFile asserts that the actual interface{} marshals to JSON
+// equivalent to the contents of the file at path. When the tests are run with
+// the -update flag, or with TESTIFY_UPDATE=1, the file is instead rewritten
+// with the indented JSON representation of actual, and the assertion passes.
+func (a *Assertions) MarshalsToJSONFile(path string, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MarshalsToJSONFile(a.t, path, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,27 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// GraphEqual asserts that the two adjacency lists describe the same graph.
+// Each node's neighbor list is treated as a set, so neither the order of
+// neighbors nor duplicate edges are significant. On failure, the missing and
+// extra nodes and edges are reported.
+func GraphEqual(t TestingT, expected, actual map[string][]string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.GraphEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// GraphEqual asserts that the two adjacency lists describe the same graph.
+// Each node's neighbor list is treated as a set, so neither the order of
+// neighbors nor duplicate edges are significant. On failure, the missing and
+// extra nodes and edges are reported.
+func (a *Assertions) GraphEqual(expected, actual map[string][]string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	GraphEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,25 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// DeepEqualJSONGzipped asserts that actualGzipped, once decompressed, is a
+// JSON document equivalent to expected. This is useful for API responses which
+// are stored gzip-compressed.
+func DeepEqualJSONGzipped(t TestingT, expected, actualGzipped []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualJSONGzipped(t, expected, actualGzipped, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualJSONGzipped asserts that actualGzipped, once decompressed, is a
+// JSON document equivalent to expected. This is useful for API responses which
+// are stored gzip-compressed.
+func (a *Assertions) DeepEqualJSONGzipped(expected, actualGzipped []byte, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualJSONGzipped(a.t, expected, actualGzipped, a.withOptions(msgAndArgs)...)
+}
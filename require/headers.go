@@ -0,0 +1,63 @@
+package require
+
+import (
+	"net/http"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// HeadersEqual asserts that the two headers are equivalent. Keys are compared
+// without regard to case. The values of headers which are unordered sets,
+// such as Vary, Allow, or Cache-Control, are compared without regard to their
+// order, or to whether they are given as separate lines or as a
+// comma-separated list; other values are compared in order. On failure, a
+// diff of only the differing headers is shown.
+func HeadersEqual(t TestingT, expected, actual http.Header, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HeadersEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HeadersEqual asserts that the two headers are equivalent. Keys are compared
+// without regard to case. The values of headers which are unordered sets,
+// such as Vary, Allow, or Cache-Control, are compared without regard to their
+// order, or to whether they are given as separate lines or as a
+// comma-separated list; other values are compared in order. On failure, a
+// diff of only the differing headers is shown.
+func (a *Assertions) HeadersEqual(expected, actual http.Header, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HeadersEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// HeaderContains asserts that actual contains each of the headers in
+// expected, and may contain others. Keys are compared without regard to case.
+// For headers which are unordered sets, such as Vary, the expected values
+// need only be among the actual values; other headers must have exactly the
+// expected values, in order. On failure, a diff of only the differing headers
+// is shown.
+func HeaderContains(t TestingT, expected, actual http.Header, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HeaderContains(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HeaderContains asserts that actual contains each of the headers in
+// expected, and may contain others. Keys are compared without regard to case.
+// For headers which are unordered sets, such as Vary, the expected values
+// need only be among the actual values; other headers must have exactly the
+// expected values, in order. On failure, a diff of only the differing headers
+// is shown.
+func (a *Assertions) HeaderContains(expected, actual http.Header, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HeaderContains(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
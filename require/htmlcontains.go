@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// HTMLContains asserts that the HTML fragment needle appears, structurally,
+// somewhere within the haystack document: some element of haystack must have
+// a run of consecutive children equivalent to the nodes of needle. Both are
+// normalized as by HTMLEqual, and the same options are accepted. Accepts
+// strings, byte arrays, *html.Node objects, or goquery selection. On failure,
+// needle is diffed against the most similar part of haystack.
+func HTMLContains(t TestingT, haystack, needle interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLContains(t, haystack, needle, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLContains asserts that the HTML fragment needle appears, structurally,
+// somewhere within the haystack document: some element of haystack must have
+// a run of consecutive children equivalent to the nodes of needle. Both are
+// normalized as by HTMLEqual, and the same options are accepted. Accepts
+// strings, byte arrays, *html.Node objects, or goquery selection. On failure,
+// needle is diffed against the most similar part of haystack.
+func (a *Assertions) HTMLContains(haystack, needle interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLContains(a.t, haystack, needle, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,53 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// HTMLSelectionEqual asserts that the elements matched by the CSS selector in
+// each of the two documents represent equivalent HTML. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. Only the matched elements
+// are compared, with the same normalization, and accepting the same options,
+// as HTMLEqual, so the rest of each page may differ freely.
+func HTMLSelectionEqual(t TestingT, selector string, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLSelectionEqual(t, selector, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLSelectionEqual asserts that the elements matched by the CSS selector in
+// each of the two documents represent equivalent HTML. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. Only the matched elements
+// are compared, with the same normalization, and accepting the same options,
+// as HTMLEqual, so the rest of each page may differ freely.
+func (a *Assertions) HTMLSelectionEqual(selector string, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLSelectionEqual(a.t, selector, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// HTMLAttrEqual asserts that the element of doc matched by the CSS selector
+// has the attribute attr, with the value expected. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The selector must match
+// exactly one element. On failure, the outer HTML of the element is reported.
+func HTMLAttrEqual(t TestingT, doc interface{}, selector, attr, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLAttrEqual(t, doc, selector, attr, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLAttrEqual asserts that the element of doc matched by the CSS selector
+// has the attribute attr, with the value expected. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The selector must match
+// exactly one element. On failure, the outer HTML of the element is reported.
+func (a *Assertions) HTMLAttrEqual(doc interface{}, selector, attr, expected string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLAttrEqual(a.t, doc, selector, attr, expected, a.withOptions(msgAndArgs)...)
+}
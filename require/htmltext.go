@@ -0,0 +1,35 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// HTMLTextEqual asserts that the two arguments have the same visible text,
+// ignoring markup entirely. Accepts strings, byte arrays, *html.Node objects,
+// or goquery selection. The text of each document is extracted with hidden
+// elements, scripts and styles omitted, and with each block-level element and
+// <br> starting a new line; whitespace within each line is collapsed, and
+// blank lines are dropped. On failure, a line-by-line diff of the text is
+// shown. The WithHTMLSelector option limits the comparison to part of each
+// document.
+func HTMLTextEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLTextEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLTextEqual asserts that the two arguments have the same visible text,
+// ignoring markup entirely. Accepts strings, byte arrays, *html.Node objects,
+// or goquery selection. The text of each document is extracted with hidden
+// elements, scripts and styles omitted, and with each block-level element and
+// <br> starting a new line; whitespace within each line is collapsed, and
+// blank lines are dropped. On failure, a line-by-line diff of the text is
+// shown. The WithHTMLSelector option limits the comparison to part of each
+// document.
+func (a *Assertions) HTMLTextEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLTextEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,39 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// HTMLValid asserts that doc, which may be a string, a []byte, or an
+// io.Reader, is structurally valid HTML, which the HTML5 parser does not need
+// to repair. Reported problems include elements left unclosed, end tags which
+// close no open element (such as the </p> of a <p> implicitly closed by a
+// <div> within it), misnested elements, elements which may not contain
+// themselves (such as an <a> within another <a>), and duplicate id
+// attributes. As in the HTML5 parser, the / of a self-closing tag such as
+// <div/> is ignored, other than for void elements and SVG or MathML content,
+// so such an element must still be closed. Each problem is reported with its
+// line number.
+func HTMLValid(t TestingT, doc interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLValid(t, doc, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLValid asserts that doc, which may be a string, a []byte, or an
+// io.Reader, is structurally valid HTML, which the HTML5 parser does not need
+// to repair. Reported problems include elements left unclosed, end tags which
+// close no open element (such as the </p> of a <p> implicitly closed by a
+// <div> within it), misnested elements, elements which may not contain
+// themselves (such as an <a> within another <a>), and duplicate id
+// attributes. As in the HTML5 parser, the / of a self-closing tag such as
+// <div/> is ignored, other than for void elements and SVG or MathML content,
+// so such an element must still be closed. Each problem is reported with its
+// line number.
+func (a *Assertions) HTMLValid(doc interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLValid(a.t, doc, a.withOptions(msgAndArgs)...)
+}
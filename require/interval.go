@@ -0,0 +1,53 @@
+package require
+
+import (
+	"time"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// IntervalEqual asserts that the interval from actualStart to actualEnd
+// matches the interval from expectedStart to expectedEnd, with each boundary
+// within tolerance of its expected value. On failure, each boundary which
+// differs is reported.
+func IntervalEqual(t TestingT, expectedStart, expectedEnd, actualStart, actualEnd time.Time, tolerance time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.IntervalEqual(t, expectedStart, expectedEnd, actualStart, actualEnd, tolerance, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// IntervalEqual asserts that the interval from actualStart to actualEnd
+// matches the interval from expectedStart to expectedEnd, with each boundary
+// within tolerance of its expected value. On failure, each boundary which
+// differs is reported.
+func (a *Assertions) IntervalEqual(expectedStart, expectedEnd, actualStart, actualEnd time.Time, tolerance time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	IntervalEqual(a.t, expectedStart, expectedEnd, actualStart, actualEnd, tolerance, a.withOptions(msgAndArgs)...)
+}
+
+// IntervalsOverlap asserts that the interval from startA to endA overlaps the
+// interval from startB to endB. Intervals which are separated by a gap no
+// greater than tolerance are considered to overlap.
+func IntervalsOverlap(t TestingT, startA, endA, startB, endB time.Time, tolerance time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.IntervalsOverlap(t, startA, endA, startB, endB, tolerance, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// IntervalsOverlap asserts that the interval from startA to endA overlaps the
+// interval from startB to endB. Intervals which are separated by a gap no
+// greater than tolerance are considered to overlap.
+func (a *Assertions) IntervalsOverlap(startA, endA, startB, endB time.Time, tolerance time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	IntervalsOverlap(a.t, startA, endA, startB, endB, tolerance, a.withOptions(msgAndArgs)...)
+}
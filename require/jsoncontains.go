@@ -0,0 +1,37 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// JSONContains asserts that the actual interface{} marshals to JSON which
+// contains the expected JSON. Every key in an expected object must be present
+// in the corresponding actual object with a matching value, but the actual
+// object may contain additional keys. Arrays are matched element by element:
+// each element of an expected array must match the element at the same index
+// of the actual array, and any trailing elements of the actual array beyond
+// the length of the expected array are ignored. With the WithUnorderedArrays
+// option, each element of an expected array may instead match any one
+// distinct element of the actual array. All other values must be equal.
+func JSONContains(t TestingT, expected []byte, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONContains(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONContains asserts that the actual interface{} marshals to JSON which
+// contains the expected JSON. Every key in an expected object must be present
+// in the corresponding actual object with a matching value, but the actual
+// object may contain additional keys. Arrays are matched element by element:
+// each element of an expected array must match the element at the same index
+// of the actual array, and any trailing elements of the actual array beyond
+// the length of the expected array are ignored. With the WithUnorderedArrays
+// option, each element of an expected array may instead match any one
+// distinct element of the actual array. All other values must be equal.
+func (a *Assertions) JSONContains(expected []byte, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONContains(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,37 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// JSONPathEqual asserts that evaluating the JSONPath expression path against
+// doc yields expected. doc and expected may each be a []byte or
+// json.RawMessage containing JSON, or any other value, which is first
+// marshaled to JSON. The supported syntax comprises the root ($), child
+// members (.name or ['name']), array indexes ([0], [-1]), slices ([1:3]),
+// wildcards (.* or [*]) and recursive descent (..name). When path contains a
+// wildcard, slice or recursive descent, the result is the array of all
+// matches; otherwise it is the single matched value. On failure, a diff of the
+// matched subtree is shown.
+func JSONPathEqual(t TestingT, doc interface{}, path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONPathEqual(t, doc, path, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONPathEqual asserts that evaluating the JSONPath expression path against
+// doc yields expected. doc and expected may each be a []byte or
+// json.RawMessage containing JSON, or any other value, which is first
+// marshaled to JSON. The supported syntax comprises the root ($), child
+// members (.name or ['name']), array indexes ([0], [-1]), slices ([1:3]),
+// wildcards (.* or [*]) and recursive descent (..name). When path contains a
+// wildcard, slice or recursive descent, the result is the array of all
+// matches; otherwise it is the single matched value. On failure, a diff of the
+// matched subtree is shown.
+func (a *Assertions) JSONPathEqual(doc interface{}, path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONPathEqual(a.t, doc, path, expected, a.withOptions(msgAndArgs)...)
+}
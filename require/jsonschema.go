@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// MatchesJSONSchema asserts that actual is valid according to the JSON Schema
+// document schema. schema and actual may each be a []byte or json.RawMessage
+// containing JSON, an io.Reader from which JSON is read, or any other value,
+// which is first marshaled to JSON. On failure, each violation is reported
+// along with the JSON pointer to the offending value.
+func MatchesJSONSchema(t TestingT, schema, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.MatchesJSONSchema(t, schema, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// MatchesJSONSchema asserts that actual is valid according to the JSON Schema
+// document schema. schema and actual may each be a []byte or json.RawMessage
+// containing JSON, an io.Reader from which JSON is read, or any other value,
+// which is first marshaled to JSON. On failure, each violation is reported
+// along with the JSON pointer to the offending value.
+func (a *Assertions) MatchesJSONSchema(schema, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MatchesJSONSchema(a.t, schema, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// MapSubset asserts that every key of the expected map is present in the
+// actual map, with a deeply equal value. The actual map may contain other
+// keys. On failure, the missing and differing keys are reported, along with a
+// diff of expected against the matching subset of actual. The comparison
+// options accepted by DeepEqual, such as WithFloatTolerance, also apply to
+// the values.
+func MapSubset(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.MapSubset(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// MapSubset asserts that every key of the expected map is present in the
+// actual map, with a deeply equal value. The actual map may contain other
+// keys. On failure, the missing and differing keys are reported, along with a
+// diff of expected against the matching subset of actual. The comparison
+// options accepted by DeepEqual, such as WithFloatTolerance, also apply to
+// the values.
+func (a *Assertions) MapSubset(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MapSubset(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,27 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// MarkdownEqual asserts that the two Markdown documents render to equivalent
+// HTML, as determined by HTMLEqual, so that insignificant differences in
+// Markdown formatting, such as the choice of emphasis or list markers, are
+// ignored.
+func MarkdownEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.MarkdownEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// MarkdownEqual asserts that the two Markdown documents render to equivalent
+// HTML, as determined by HTMLEqual, so that insignificant differences in
+// Markdown formatting, such as the choice of emphasis or list markers, are
+// ignored.
+func (a *Assertions) MarkdownEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MarkdownEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
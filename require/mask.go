@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// DeepEqualExcept asserts that two objects are deeply equal, ignoring the
+// struct fields named in ignoreFields. Fields are matched by their Go field
+// name at any depth, including within nested structs, pointers, slices, arrays
+// and maps, so that passing "CreatedAt" ignores every CreatedAt field in the
+// tree. Only exported fields, and values reachable through them, are masked.
+// Neither expected nor actual is modified.
+func DeepEqualExcept(t TestingT, expected, actual interface{}, ignoreFields []string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualExcept(t, expected, actual, ignoreFields, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualExcept asserts that two objects are deeply equal, ignoring the
+// struct fields named in ignoreFields. Fields are matched by their Go field
+// name at any depth, including within nested structs, pointers, slices, arrays
+// and maps, so that passing "CreatedAt" ignores every CreatedAt field in the
+// tree. Only exported fields, and values reachable through them, are masked.
+// Neither expected nor actual is modified.
+func (a *Assertions) DeepEqualExcept(expected, actual interface{}, ignoreFields []string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualExcept(a.t, expected, actual, ignoreFields, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,27 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// MsgpackEqual asserts that the two MessagePack-encoded byte slices decode to
+// deeply equal values. Numbers are compared regardless of the width with
+// which they were encoded. On failure, a structured diff of the decoded values
+// is shown.
+func MsgpackEqual(t TestingT, expected, actual []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.MsgpackEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// MsgpackEqual asserts that the two MessagePack-encoded byte slices decode to
+// deeply equal values. Numbers are compared regardless of the width with
+// which they were encoded. On failure, a structured diff of the decoded values
+// is shown.
+func (a *Assertions) MsgpackEqual(expected, actual []byte, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MsgpackEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
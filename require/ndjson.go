@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// NDJSONEqual asserts that the two NDJSON (newline-delimited JSON, or JSON
+// Lines) documents contain semantically equal records in the same order. Each
+// non-blank line is parsed as a JSON value, so differences in whitespace or
+// key order within a record are ignored. On failure, the line numbers of the
+// mismatched records are reported, followed by a diff of the records, each
+// rendered as canonical JSON on a single line.
+func NDJSONEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NDJSONEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NDJSONEqual asserts that the two NDJSON (newline-delimited JSON, or JSON
+// Lines) documents contain semantically equal records in the same order. Each
+// non-blank line is parsed as a JSON value, so differences in whitespace or
+// key order within a record are ignored. On failure, the line numbers of the
+// mismatched records are reported, followed by a diff of the records, each
+// rendered as canonical JSON on a single line.
+func (a *Assertions) NDJSONEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NDJSONEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
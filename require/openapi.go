@@ -0,0 +1,35 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// OpenAPIResponseValid asserts that actual is valid according to the response
+// schema defined in the OpenAPI spec found at specPath, for the operation
+// identified by operationID and the given HTTP status code. If the operation
+// defines no response for statusCode, the default response is used. The
+// schema is that of its application/json content or, failing that, of another
+// JSON media type, such as application/problem+json. actual may be a []byte
+// or json.RawMessage containing JSON, or any value which will be marshaled to
+// JSON.
+func OpenAPIResponseValid(t TestingT, specPath, operationID string, statusCode int, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.OpenAPIResponseValid(t, specPath, operationID, statusCode, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// OpenAPIResponseValid asserts that actual is valid according to the response
+// schema defined in the OpenAPI spec found at specPath, for the operation
+// identified by operationID and the given HTTP status code. If the operation
+// defines no response for statusCode, the default response is used. The
+// schema is that of its application/json content or, failing that, of another
+// JSON media type, such as application/problem+json. actual may be a []byte
+// or json.RawMessage containing JSON, or any value which will be marshaled to
+// JSON.
+func (a *Assertions) OpenAPIResponseValid(specPath, operationID string, statusCode int, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	OpenAPIResponseValid(a.t, specPath, operationID, statusCode, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// PatchEqual asserts that the two strings, which must be in unified diff
+// format, describe the same changes. Context lines and hunk header line
+// numbers are ignored, so that patches generated with differing amounts of
+// context, or against slightly shifted sources, compare equal so long as they
+// add and remove the same lines of the same files.
+func PatchEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.PatchEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// PatchEqual asserts that the two strings, which must be in unified diff
+// format, describe the same changes. Context lines and hunk header line
+// numbers are ignored, so that patches generated with differing amounts of
+// context, or against slightly shifted sources, compare equal so long as they
+// add and remove the same lines of the same files.
+func (a *Assertions) PatchEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	PatchEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,29 @@
+package require
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/flimzy/testify/assert"
+)
+
+// ProtoEqual asserts that the two protocol buffer messages are equal, as
+// determined by proto.Equal. On failure, a diff of the messages in text
+// format is shown.
+func ProtoEqual(t TestingT, expected, actual proto.Message, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.ProtoEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// ProtoEqual asserts that the two protocol buffer messages are equal, as
+// determined by proto.Equal. On failure, a diff of the messages in text
+// format is shown.
+func (a *Assertions) ProtoEqual(expected, actual proto.Message, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ProtoEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
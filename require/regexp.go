@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// RegexpMatchesLines asserts that each line of actual matches the regular
+// expression at the same position in patterns, and that actual has exactly
+// as many lines as there are patterns. Each pattern must match the whole of
+// its line. A single trailing newline in actual is ignored. On failure, a
+// line-by-line listing is shown, in which each line that fails to match is
+// preceded by the pattern it failed.
+func RegexpMatchesLines(t TestingT, patterns []string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.RegexpMatchesLines(t, patterns, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// RegexpMatchesLines asserts that each line of actual matches the regular
+// expression at the same position in patterns, and that actual has exactly
+// as many lines as there are patterns. Each pattern must match the whole of
+// its line. A single trailing newline in actual is ignored. On failure, a
+// line-by-line listing is shown, in which each line that fails to match is
+// preceded by the pattern it failed.
+func (a *Assertions) RegexpMatchesLines(patterns []string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	RegexpMatchesLines(a.t, patterns, actual, a.withOptions(msgAndArgs)...)
+}
@@ -12,23 +12,53 @@ type TestingT interface {
 	FailNow()
 }
 
+// tHelper is implemented by *testing.T. If a TestingT also implements it,
+// assertions mark themselves as test helpers, so that failures are attributed
+// to the calling test.
+type tHelper interface {
+	Helper()
+}
+
 // Assertions provides assertion methods around the TestingT interface.
 type Assertions struct {
 	*require.Assertions
-	t TestingT
+	t    TestingT
+	opts []assert.Option
 }
 
-// New makes a new Assertions object for the specified TestingT.
-func New(t TestingT) *Assertions {
+// New makes a new Assertions object for the specified TestingT. Any opts are
+// applied to every assertion made through it, before any options passed to
+// the individual assertion.
+func New(t TestingT, opts ...assert.Option) *Assertions {
 	require := require.New(t)
 	return &Assertions{
 		Assertions: require,
 		t:          t,
+		opts:       opts,
 	}
 }
 
-// DeepEqual asserts that two objects are deeply equal.
+// withOptions returns msgAndArgs, preceded by the options of a.
+func (a *Assertions) withOptions(msgAndArgs []interface{}) []interface{} {
+	if len(a.opts) == 0 {
+		return msgAndArgs
+	}
+	args := make([]interface{}, 0, len(a.opts)+len(msgAndArgs))
+	for _, opt := range a.opts {
+		args = append(args, opt)
+	}
+	return append(args, msgAndArgs...)
+}
+
+// DeepEqual asserts that two objects are deeply equal. Values of any type for
+// which a Comparator has been registered with RegisterComparator are compared
+// with that comparator. The WithCollapsedMaps option may be passed to limit the
+// diff of large maps to the changed entries, or WithBreadcrumbContext to show
+// each change beneath the path of fields which contain it.
 func DeepEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.DeepEqual(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -36,14 +66,21 @@ func DeepEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface
 
 // DeepEqual asserts that two objects are deeply equal.
 func (a *Assertions) DeepEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
-	DeepEqual(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // DeepEqualJSON marshals the expected and actual interfaces to JSON, then
 // unmarshals before doing a reflect.DeepEqual check on them. If they are
 // unequal, a diff of their respective JSON representations is produced as
-// output.
+// output. The WithIgnoredJSONPaths option excludes volatile values, such as
+// generated IDs or timestamps, from the comparison.
 func DeepEqualJSON(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.DeepEqualJSON(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -52,14 +89,63 @@ func DeepEqualJSON(t TestingT, expected, actual interface{}, msgAndArgs ...inter
 // DeepEqualJSON marshals the expected and actual interfaces to JSON, then
 // unmarshals before doing a reflect.DeepEqual check on them. If they are
 // unequal, a diff of their respective JSON representations is produced as
-// output.
+// output. The WithIgnoredJSONPaths option excludes volatile values, such as
+// generated IDs or timestamps, from the comparison.
 func (a *Assertions) DeepEqualJSON(expected, actual interface{}, msgAndArgs ...interface{}) {
-	DeepEqualJSON(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualJSON(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// DeepEqualJSONWithPatch behaves like DeepEqualJSON, but on failure also
+// reports the RFC 6902 JSON Patch which would transform expected into actual.
+func DeepEqualJSONWithPatch(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualJSONWithPatch(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualJSONWithPatch behaves like DeepEqualJSON, but on failure also
+// reports the RFC 6902 JSON Patch which would transform expected into actual.
+func (a *Assertions) DeepEqualJSONWithPatch(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualJSONWithPatch(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// DeepEqualJSONSummary behaves like DeepEqualJSON, but on failure also
+// reports a plain-English summary of the changes, such as "3 fields changed,
+// 1 added, 2 removed", followed by one line per change, before the full diff.
+func DeepEqualJSONSummary(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualJSONSummary(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualJSONSummary behaves like DeepEqualJSON, but on failure also
+// reports a plain-English summary of the changes, such as "3 fields changed,
+// 1 added, 2 removed", followed by one line per change, before the full diff.
+func (a *Assertions) DeepEqualJSONSummary(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualJSONSummary(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // MarshalsToJSON asserts that the actual interface{} marshals to the expected
 // JSON.
 func MarshalsToJSON(t TestingT, expected []byte, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.MarshalsToJSON(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -68,12 +154,89 @@ func MarshalsToJSON(t TestingT, expected []byte, actual interface{}, msgAndArgs
 // MarshalsToJSON asserts that the actual interface{} marshals to the expected
 // JSON.
 func (a *Assertions) MarshalsToJSON(expected []byte, actual interface{}, msgAndArgs ...interface{}) {
-	MarshalsToJSON(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MarshalsToJSON(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// UnmarshalsFromJSON asserts that data unmarshals into a value of the same
+// type as expected, which deeply equals expected. It is the inverse of
+// MarshalsToJSON.
+func UnmarshalsFromJSON(t TestingT, data []byte, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.UnmarshalsFromJSON(t, data, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// UnmarshalsFromJSON asserts that data unmarshals into a value of the same
+// type as expected, which deeply equals expected. It is the inverse of
+// MarshalsToJSON.
+func (a *Assertions) UnmarshalsFromJSON(data []byte, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	UnmarshalsFromJSON(a.t, data, expected, a.withOptions(msgAndArgs)...)
+}
+
+// JSONRoundTrip asserts that marshaling value to JSON, then unmarshaling the
+// result into a new value of the same type, yields a value which deeply
+// equals the original. This catches lossy MarshalJSON and UnmarshalJSON
+// implementations.
+func JSONRoundTrip(t TestingT, value interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONRoundTrip(t, value, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONRoundTrip asserts that marshaling value to JSON, then unmarshaling the
+// result into a new value of the same type, yields a value which deeply
+// equals the original. This catches lossy MarshalJSON and UnmarshalJSON
+// implementations.
+func (a *Assertions) JSONRoundTrip(value interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONRoundTrip(a.t, value, a.withOptions(msgAndArgs)...)
+}
+
+// DeepEqualJSON5 asserts that the actual interface{} marshals to JSON
+// equivalent to the expected JSON5 document. JSON5 permits comments, trailing
+// commas, unquoted keys and other conveniences, which makes it well suited to
+// annotated fixtures.
+func DeepEqualJSON5(t TestingT, expected []byte, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualJSON5(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualJSON5 asserts that the actual interface{} marshals to JSON
+// equivalent to the expected JSON5 document. JSON5 permits comments, trailing
+// commas, unquoted keys and other conveniences, which makes it well suited to
+// annotated fixtures.
+func (a *Assertions) DeepEqualJSON5(expected []byte, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualJSON5(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // LinesEqual asserts that the two strings are equal, or shows a line-by-line
-// diff of their differences.
+// diff of their differences. The diff algorithm may be selected with the
+// WithDiffAlgorithm option.
 func LinesEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.LinesEqual(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -82,19 +245,64 @@ func LinesEqual(t TestingT, expected, actual string, msgAndArgs ...interface{})
 // LinesEqual asserts that the two strings are equal, or shows a line-by-line
 // diff of their differences.
 func (a *Assertions) LinesEqual(expected, actual string, msgAndArgs ...interface{}) {
-	LinesEqual(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	LinesEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
 
 // HTMLEqual asserts that the two arguments represent equivalent HTML. Accepts
-// strings, byte arrays, *html.Node objects, or goquery selection.
+// strings, byte arrays, *html.Node objects, or goquery selection. Both
+// documents are normalized before comparison, so that attribute order, the
+// order of class names, and insignificant whitespace do not matter. Comments
+// may be ignored with the WithIgnoreHTMLComments option, and the WithXHTML
+// option requires both documents to be well-formed XML. Use HTMLEqualStrict
+// to compare the documents exactly as parsed.
 func HTMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.HTMLEqual(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
 }
 
 // HTMLEqual asserts that the two arguments represent equivalent HTML. Accepts
-// strings, byte arrays, *html.Node objects, or goquery selection.
+// strings, byte arrays, *html.Node objects, or goquery selection. Both
+// documents are normalized before comparison, so that attribute order, the
+// order of class names, and insignificant whitespace do not matter. Comments
+// may be ignored with the WithIgnoreHTMLComments option, and the WithXHTML
+// option requires both documents to be well-formed XML. Use HTMLEqualStrict
+// to compare the documents exactly as parsed.
 func (a *Assertions) HTMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
-	HTMLEqual(a.t, expected, actual, msgAndArgs...)
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// HTMLEqualStrict asserts that the two arguments parse to identical HTML
+// trees, including attribute order and all whitespace. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The
+// WithIgnoreHTMLWhitespace and WithIgnoreHTMLAttributeOrder options relax the
+// comparison of whitespace and attributes respectively.
+func HTMLEqualStrict(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HTMLEqualStrict(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HTMLEqualStrict asserts that the two arguments parse to identical HTML
+// trees, including attribute order and all whitespace. Accepts strings, byte
+// arrays, *html.Node objects, or goquery selection. The
+// WithIgnoreHTMLWhitespace and WithIgnoreHTMLAttributeOrder options relax the
+// comparison of whitespace and attributes respectively.
+func (a *Assertions) HTMLEqualStrict(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	HTMLEqualStrict(a.t, expected, actual, a.withOptions(msgAndArgs)...)
 }
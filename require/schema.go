@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// DeepEqualIgnoringNewFields asserts that newer is deeply equal to older,
+// considering only those fields which exist in older's type. This is useful
+// when comparing values produced by two versions of a schema, where the newer
+// version may have gained fields. Fields are matched by name, at any depth.
+// Values of the same type in both, such as time.Time, are compared whole;
+// otherwise, unexported fields are not compared.
+func DeepEqualIgnoringNewFields(t TestingT, older, newer interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DeepEqualIgnoringNewFields(t, older, newer, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DeepEqualIgnoringNewFields asserts that newer is deeply equal to older,
+// considering only those fields which exist in older's type. This is useful
+// when comparing values produced by two versions of a schema, where the newer
+// version may have gained fields. Fields are matched by name, at any depth.
+// Values of the same type in both, such as time.Time, are compared whole;
+// otherwise, unexported fields are not compared.
+func (a *Assertions) DeepEqualIgnoringNewFields(older, newer interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DeepEqualIgnoringNewFields(a.t, older, newer, a.withOptions(msgAndArgs)...)
+}
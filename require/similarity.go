@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// SimilarityAtLeast asserts that the similarity ratio of expected and actual
+// is at least minRatio. The ratio, between 0 and 1, is computed by difflib's
+// sequence matcher over the characters of the two strings, where 1 means the
+// strings are identical. This is useful for output which should be "mostly"
+// the same.
+func SimilarityAtLeast(t TestingT, expected, actual string, minRatio float64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.SimilarityAtLeast(t, expected, actual, minRatio, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// SimilarityAtLeast asserts that the similarity ratio of expected and actual
+// is at least minRatio. The ratio, between 0 and 1, is computed by difflib's
+// sequence matcher over the characters of the two strings, where 1 means the
+// strings are identical. This is useful for output which should be "mostly"
+// the same.
+func (a *Assertions) SimilarityAtLeast(expected, actual string, minRatio float64, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	SimilarityAtLeast(a.t, expected, actual, minRatio, a.withOptions(msgAndArgs)...)
+}
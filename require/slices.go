@@ -0,0 +1,73 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// ContainsInOrder asserts that the elements of expectedSubsequence appear
+// within actual in the same order, though not necessarily contiguously, so
+// actual may contain extra interleaved elements. Both arguments must be
+// slices or arrays. Elements are compared with reflect.DeepEqual.
+func ContainsInOrder(t TestingT, actual, expectedSubsequence interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.ContainsInOrder(t, actual, expectedSubsequence, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// ContainsInOrder asserts that the elements of expectedSubsequence appear
+// within actual in the same order, though not necessarily contiguously, so
+// actual may contain extra interleaved elements. Both arguments must be
+// slices or arrays. Elements are compared with reflect.DeepEqual.
+func (a *Assertions) ContainsInOrder(actual, expectedSubsequence interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ContainsInOrder(a.t, actual, expectedSubsequence, a.withOptions(msgAndArgs)...)
+}
+
+// EqualByKey asserts that the expected and actual slices are equal when each
+// element is projected through keyFn. This allows, for instance, comparing two
+// slices of users by their IDs alone.
+func EqualByKey(t TestingT, expected, actual interface{}, keyFn func(interface{}) interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.EqualByKey(t, expected, actual, keyFn, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// EqualByKey asserts that the expected and actual slices are equal when each
+// element is projected through keyFn. This allows, for instance, comparing two
+// slices of users by their IDs alone.
+func (a *Assertions) EqualByKey(expected, actual interface{}, keyFn func(interface{}) interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EqualByKey(a.t, expected, actual, keyFn, a.withOptions(msgAndArgs)...)
+}
+
+// SlicesEqualFunc asserts that the expected and actual slices have the same
+// length, and that eq returns true for each pair of corresponding elements.
+// This allows arbitrary per-element rules, such as numeric tolerances. On
+// failure, the first index at which eq returned false is reported.
+func SlicesEqualFunc(t TestingT, expected, actual interface{}, eq func(a, b interface{}) bool, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.SlicesEqualFunc(t, expected, actual, eq, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// SlicesEqualFunc asserts that the expected and actual slices have the same
+// length, and that eq returns true for each pair of corresponding elements.
+// This allows arbitrary per-element rules, such as numeric tolerances. On
+// failure, the first index at which eq returned false is reported.
+func (a *Assertions) SlicesEqualFunc(expected, actual interface{}, eq func(a, b interface{}) bool, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	SlicesEqualFunc(a.t, expected, actual, eq, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,33 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// Snapshot asserts that the dump of value matches the snapshot stored for the
+// test, in testdata/snapshots/<test name>.snap, where subtests are separated
+// by underscores. The value is dumped by spew, as for the diffs of DeepEqual,
+// but without pointer addresses or capacities, so that it is stable from run
+// to run. When the tests are run with the -update flag, or with
+// TESTIFY_UPDATE=1, the snapshot is instead written, and the assertion
+// passes. A test may have only one snapshot; use subtests for more.
+func Snapshot(t TestingT, value interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Snapshot(t, value, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Snapshot asserts that the dump of value matches the snapshot stored for the
+// test, in testdata/snapshots/<test name>.snap, where subtests are separated
+// by underscores. The value is dumped by spew, as for the diffs of DeepEqual,
+// but without pointer addresses or capacities, so that it is stable from run
+// to run. When the tests are run with the -update flag, or with
+// TESTIFY_UPDATE=1, the snapshot is instead written, and the assertion
+// passes. A test may have only one snapshot; use subtests for more.
+func (a *Assertions) Snapshot(value interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Snapshot(a.t, value, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,55 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// SQLEqual asserts that the two SQL statements are equivalent once
+// normalized, so that differences in whitespace, comments, keyword case and
+// trailing semicolons are ignored. The WithNormalizedPlaceholders option may
+// be passed to also ignore differences in placeholder style. On failure, a
+// word-level diff of the normalized statements is shown.
+func SQLEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.SQLEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// SQLEqual asserts that the two SQL statements are equivalent once
+// normalized, so that differences in whitespace, comments, keyword case and
+// trailing semicolons are ignored. The WithNormalizedPlaceholders option may
+// be passed to also ignore differences in placeholder style. On failure, a
+// word-level diff of the normalized statements is shown.
+func (a *Assertions) SQLEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	SQLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// MigrationsEqual asserts that the two lists of SQL migration statements are
+// equivalent. Each statement is normalized before comparison, so that
+// differences in whitespace, comments, keyword case and trailing semicolons
+// are ignored. On failure, the first statement which differs is reported,
+// along with a diff of the normalized statements.
+func MigrationsEqual(t TestingT, expected, actual []string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.MigrationsEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// MigrationsEqual asserts that the two lists of SQL migration statements are
+// equivalent. Each statement is normalized before comparison, so that
+// differences in whitespace, comments, keyword case and trailing semicolons
+// are ignored. On failure, the first statement which differs is reported,
+// along with a diff of the normalized statements.
+func (a *Assertions) MigrationsEqual(expected, actual []string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	MigrationsEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,31 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// TemplatesEquivalent asserts that the two templates produce equivalent output
+// when executed with the same data. This is useful for verifying that a
+// refactored template is unchanged in effect. The templates may be of type
+// *html/template.Template, in which case the outputs are compared with
+// HTMLEqual, or *text/template.Template, in which case the outputs are
+// compared with LinesEqual.
+func TemplatesEquivalent(t TestingT, tmplA, tmplB, data interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.TemplatesEquivalent(t, tmplA, tmplB, data, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// TemplatesEquivalent asserts that the two templates produce equivalent output
+// when executed with the same data. This is useful for verifying that a
+// refactored template is unchanged in effect. The templates may be of type
+// *html/template.Template, in which case the outputs are compared with
+// HTMLEqual, or *text/template.Template, in which case the outputs are
+// compared with LinesEqual.
+func (a *Assertions) TemplatesEquivalent(tmplA, tmplB, data interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	TemplatesEquivalent(a.t, tmplA, tmplB, data, a.withOptions(msgAndArgs)...)
+}
@@ -0,0 +1,25 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// TOMLEqual asserts that the expected and actual TOML documents are
+// semantically equivalent, ignoring key order, formatting and comments. On
+// failure, a diff of the documents re-serialized in canonical form is shown.
+func TOMLEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.TOMLEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// TOMLEqual asserts that the expected and actual TOML documents are
+// semantically equivalent, ignoring key order, formatting and comments. On
+// failure, a diff of the documents re-serialized in canonical form is shown.
+func (a *Assertions) TOMLEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	TOMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
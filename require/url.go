@@ -0,0 +1,75 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// PathEqual asserts that the two URL paths are equal. Paths are compared
+// exactly, unless the WithIgnoreTrailingSlash option is passed.
+func PathEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.PathEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// PathEqual asserts that the two URL paths are equal. Paths are compared
+// exactly, unless the WithIgnoreTrailingSlash option is passed.
+func (a *Assertions) PathEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	PathEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// URLEqual asserts that the two URLs are equivalent. Both are parsed and
+// their parts compared individually: the scheme and host are compared
+// case-insensitively, and query parameters are treated as an unordered
+// multimap, so that neither the order of parameters nor that of repeated
+// values matters. The path honors the WithIgnoreTrailingSlash option. On
+// failure, a diff of the decomposed URLs is shown.
+func URLEqual(t TestingT, expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.URLEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// URLEqual asserts that the two URLs are equivalent. Both are parsed and
+// their parts compared individually: the scheme and host are compared
+// case-insensitively, and query parameters are treated as an unordered
+// multimap, so that neither the order of parameters nor that of repeated
+// values matters. The path honors the WithIgnoreTrailingSlash option. On
+// failure, a diff of the decomposed URLs is shown.
+func (a *Assertions) URLEqual(expected, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	URLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
+
+// QueryValuesEqual asserts that the two sets of query values are equal,
+// treating them as unordered multimaps. Accepts url.Values,
+// map[string][]string, or raw query strings, with or without a leading "?".
+// On failure, the missing, unexpected and mismatched keys are listed.
+func QueryValuesEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.QueryValuesEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// QueryValuesEqual asserts that the two sets of query values are equal,
+// treating them as unordered multimaps. Accepts url.Values,
+// map[string][]string, or raw query strings, with or without a leading "?".
+// On failure, the missing, unexpected and mismatched keys are listed.
+func (a *Assertions) QueryValuesEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	QueryValuesEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
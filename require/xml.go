@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// XMLEqual asserts that the two arguments represent equivalent XML documents.
+// Accepts strings, byte arrays, or io.Readers. Whitespace between elements and
+// surrounding text, attribute order, namespace prefixes, comments and
+// processing instructions are ignored. On failure, a diff of the normalized
+// documents is shown.
+func XMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.XMLEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// XMLEqual asserts that the two arguments represent equivalent XML documents.
+// Accepts strings, byte arrays, or io.Readers. Whitespace between elements and
+// surrounding text, attribute order, namespace prefixes, comments and
+// processing instructions are ignored. On failure, a diff of the normalized
+// documents is shown.
+func (a *Assertions) XMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	XMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}
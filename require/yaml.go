@@ -0,0 +1,29 @@
+package require
+
+import "github.com/flimzy/testify/assert"
+
+// YAMLEqual asserts that expected and actual represent equivalent YAML
+// documents. Strings and byte arrays are parsed as YAML, and may contain
+// several documents, which are compared in order; any other value is first
+// marshaled to YAML. On failure, a diff of the canonical YAML
+// representations, with sorted keys, is shown.
+func YAMLEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.YAMLEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// YAMLEqual asserts that expected and actual represent equivalent YAML
+// documents. Strings and byte arrays are parsed as YAML, and may contain
+// several documents, which are compared in order; any other value is first
+// marshaled to YAML. On failure, a diff of the canonical YAML
+// representations, with sorted keys, is shown.
+func (a *Assertions) YAMLEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	YAMLEqual(a.t, expected, actual, a.withOptions(msgAndArgs)...)
+}